@@ -0,0 +1,101 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// ArchitectureMismatch is an ELF file that is not built for the target architecture.
+type ArchitectureMismatch struct {
+	Path         string
+	Architecture string
+}
+
+type ArchitectureVerifier struct {
+	ApplicationPath string
+	Logger          bard.Logger
+}
+
+// Verify scans the ELF headers of all regular files in the application and returns those not built for arch.  Native
+// library directories for other architectures, such as lib/native/linux-aarch64 when arch is amd64, and files that
+// cannot be read or parsed are skipped.
+func (a ArchitectureVerifier) Verify(arch string) ([]ArchitectureMismatch, error) {
+	var mismatches []ArchitectureMismatch
+
+	err := filepath.WalkDir(a.ApplicationPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if dirArch, ok := nativeDirectoryArch(d.Name()); ok && dirArch != arch {
+				a.Logger.Debugf("skipping %s: native libraries for %s", path, dirArch)
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		actual, ok, err := ELFArch(path)
+		if err != nil {
+			a.Logger.Debugf("ignoring %s: %s", path, err)
+			return nil
+		}
+		if !ok {
+			return nil
+		}
+
+		rel, err := filepath.Rel(a.ApplicationPath, path)
+		if err != nil {
+			return fmt.Errorf("unable to find relative path of %s\n%w", path, err)
+		}
+
+		a.Logger.Debugf("%s is built for %s", rel, actual)
+		if actual != arch {
+			mismatches = append(mismatches, ArchitectureMismatch{Path: rel, Architecture: actual})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan %s for native files\n%w", a.ApplicationPath, err)
+	}
+
+	return mismatches, nil
+}
+
+// nativeDirectoryArch returns the architecture of a native library directory named linux-<alias>, and false if name is
+// not such a directory.
+func nativeDirectoryArch(name string) (string, bool) {
+	name = strings.ToLower(name)
+	for arch, aliases := range ArchitectureAliases {
+		for _, alias := range aliases {
+			if name == fmt.Sprintf("linux-%s", alias) {
+				return arch, true
+			}
+		}
+	}
+
+	return "", false
+}
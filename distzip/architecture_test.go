@@ -0,0 +1,87 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testArchitecture(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		v distzip.ArchitectureVerifier
+	)
+
+	it.Before(func() {
+		v.ApplicationPath = t.TempDir()
+
+		Expect(os.MkdirAll(filepath.Join(v.ApplicationPath, "app", "bin"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(v.ApplicationPath, "app", "bin", "app"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+		writeELF(t, filepath.Join(v.ApplicationPath, "app", "bin", "helper"), elf.EM_X86_64)
+		writeELF(t, filepath.Join(v.ApplicationPath, "app", "lib", "native", "libalpha.so"), elf.EM_AARCH64)
+	})
+
+	it("returns files not built for the architecture", func() {
+		Expect(v.Verify("amd64")).To(Equal([]distzip.ArchitectureMismatch{
+			{Path: filepath.Join("app", "lib", "native", "libalpha.so"), Architecture: "arm64"},
+		}))
+
+		Expect(v.Verify("arm64")).To(Equal([]distzip.ArchitectureMismatch{
+			{Path: filepath.Join("app", "bin", "helper"), Architecture: "amd64"},
+		}))
+	})
+
+	it("ignores symlinks", func() {
+		Expect(os.Symlink("libalpha.so", filepath.Join(v.ApplicationPath, "app", "lib", "native", "libalpha.so.1"))).To(Succeed())
+
+		Expect(v.Verify("amd64")).To(HaveLen(1))
+	})
+
+	it("returns nothing if all files match", func() {
+		Expect(os.Remove(filepath.Join(v.ApplicationPath, "app", "bin", "helper"))).To(Succeed())
+
+		Expect(v.Verify("arm64")).To(BeEmpty())
+	})
+
+	it("skips native library directories for other architectures", func() {
+		Expect(os.Remove(filepath.Join(v.ApplicationPath, "app", "lib", "native", "libalpha.so"))).To(Succeed())
+		writeELF(t, filepath.Join(v.ApplicationPath, "app", "lib", "native", "linux-x86_64", "libbravo.so"), elf.EM_X86_64)
+		writeELF(t, filepath.Join(v.ApplicationPath, "app", "lib", "native", "linux-aarch64", "libbravo.so"), elf.EM_AARCH64)
+
+		Expect(v.Verify("amd64")).To(BeEmpty())
+		Expect(v.Verify("arm64")).To(Equal([]distzip.ArchitectureMismatch{
+			{Path: filepath.Join("app", "bin", "helper"), Architecture: "amd64"},
+		}))
+	})
+
+	it("skips files that cannot be parsed", func() {
+		Expect(os.WriteFile(filepath.Join(v.ApplicationPath, "app", "bin", "truncated"), []byte("\x7fELF\x02"), 0755)).To(Succeed())
+
+		Expect(v.Verify("amd64")).To(Equal([]distzip.ArchitectureMismatch{
+			{Path: filepath.Join("app", "lib", "native", "libalpha.so"), Architecture: "arm64"},
+		}))
+	})
+}
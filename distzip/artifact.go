@@ -0,0 +1,235 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// Artifact is a jar in the distribution, identified by its Maven coordinates.
+type Artifact struct {
+	Path       string
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// Coordinates returns the groupId:artifactId of the artifact, or only the artifactId if the groupId is unknown.
+func (a Artifact) Coordinates() string {
+	if a.GroupID == "" {
+		return a.ArtifactID
+	}
+	return fmt.Sprintf("%s:%s", a.GroupID, a.ArtifactID)
+}
+
+func (a Artifact) String() string {
+	return fmt.Sprintf("%s:%s", a.Coordinates(), a.Version)
+}
+
+var artifactFileName = regexp.MustCompile(`^(.+?)-(\d[\w.+-]*)\.jar$`)
+
+// NestedJarSeparator separates the path of a jar from the path of a jar nested within it, such as
+// lib/app.jar!/BOOT-INF/lib/log4j-core-2.14.1.jar.
+const NestedJarSeparator = "!/"
+
+// OpenJar opens the jar at file, which may be nested within other jars using NestedJarSeparator.
+func OpenJar(file string) (*zip.Reader, io.Closer, error) {
+	parts := strings.Split(file, NestedJarSeparator)
+
+	z, err := zip.OpenReader(parts[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open %s\n%w", parts[0], err)
+	}
+
+	r := &z.Reader
+	for _, p := range parts[1:] {
+		f := slices.IndexFunc(r.File, func(f *zip.File) bool { return f.Name == p })
+		if f == -1 {
+			z.Close()
+			return nil, nil, fmt.Errorf("unable to find %s in %s", p, file)
+		}
+
+		if r, err = openNestedJar(r.File[f]); err != nil {
+			z.Close()
+			return nil, nil, fmt.Errorf("unable to open %s in %s\n%w", p, file, err)
+		}
+	}
+
+	return r, z, nil
+}
+
+func openNestedJar(f *zip.File) (*zip.Reader, error) {
+	var b []byte
+	if err := readZipEntry(f, func(in io.Reader) (err error) { b, err = io.ReadAll(in); return err }); err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(b), int64(len(b)))
+}
+
+// ReadArtifact returns the coordinates of a jar from its META-INF/maven/**/pom.properties, preferring the one
+// matching the file name if the jar shades others, and falls back to parsing the file name.
+func ReadArtifact(file string) (Artifact, error) {
+	z, c, err := OpenJar(file)
+	if err != nil {
+		return Artifact{}, err
+	}
+	defer c.Close()
+
+	return readArtifact(z, file)
+}
+
+func readArtifact(z *zip.Reader, file string) (Artifact, error) {
+	var candidates []Artifact
+	for _, f := range z.File {
+		if !strings.HasPrefix(f.Name, "META-INF/maven/") || path.Base(f.Name) != "pom.properties" {
+			continue
+		}
+
+		in, err := f.Open()
+		if err != nil {
+			return Artifact{}, fmt.Errorf("unable to open %s in %s\n%w", f.Name, file, err)
+		}
+
+		p := map[string]string{}
+		s := bufio.NewScanner(in)
+		for s.Scan() {
+			line := strings.TrimSpace(s.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if k, v, ok := strings.Cut(line, "="); ok {
+				p[strings.TrimSpace(k)] = strings.TrimSpace(v)
+			}
+		}
+		in.Close()
+		if err := s.Err(); err != nil {
+			return Artifact{}, fmt.Errorf("unable to read %s in %s\n%w", f.Name, file, err)
+		}
+
+		if p["artifactId"] != "" {
+			candidates = append(candidates, Artifact{Path: file, GroupID: p["groupId"], ArtifactID: p["artifactId"], Version: p["version"]})
+		}
+	}
+
+	for _, c := range candidates {
+		if strings.HasPrefix(filepath.Base(file), c.ArtifactID+"-") {
+			return c, nil
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	a := Artifact{Path: file, ArtifactID: strings.TrimSuffix(filepath.Base(file), ".jar")}
+	if m := artifactFileName.FindStringSubmatch(filepath.Base(file)); m != nil {
+		a.ArtifactID, a.Version = m[1], m[2]
+	}
+	return a, nil
+}
+
+type ArtifactResolver struct {
+	Logger bard.Logger
+}
+
+// Resolve returns the artifacts of the jars in the lib directory of the distribution.
+func (a ArtifactResolver) Resolve(home string) ([]Artifact, error) {
+	files, err := filepath.Glob(filepath.Join(home, "lib", "*.jar"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to find jars in %s\n%w", home, err)
+	}
+
+	var artifacts []Artifact
+	for _, f := range files {
+		artifact, err := ReadArtifact(f)
+		if err != nil {
+			a.Logger.Debugf("ignoring %s: %s", f, err)
+			continue
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+// ResolveTree returns the artifacts of all jars below root, including jars nested within them, such as the
+// BOOT-INF/lib jars of Spring Boot applications or the WEB-INF/lib jars of web applications.
+func (a ArtifactResolver) ResolveTree(root string) ([]Artifact, error) {
+	var artifacts []Artifact
+
+	err := filepath.WalkDir(root, func(file string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(file) != ".jar" {
+			return nil
+		}
+
+		z, err := zip.OpenReader(file)
+		if err != nil {
+			a.Logger.Debugf("ignoring %s: %s", file, err)
+			return nil
+		}
+		defer z.Close()
+
+		artifacts = append(artifacts, a.resolveJar(&z.Reader, file)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find jars in %s\n%w", root, err)
+	}
+
+	return artifacts, nil
+}
+
+// resolveJar returns the artifact of the jar z at file and those of the jars nested within it.
+func (a ArtifactResolver) resolveJar(z *zip.Reader, file string) []Artifact {
+	artifact, err := readArtifact(z, file)
+	if err != nil {
+		a.Logger.Debugf("ignoring %s: %s", file, err)
+		return nil
+	}
+	artifacts := []Artifact{artifact}
+
+	for _, f := range z.File {
+		if f.FileInfo().IsDir() || path.Ext(f.Name) != ".jar" {
+			continue
+		}
+
+		nested := file + NestedJarSeparator + f.Name
+		n, err := openNestedJar(f)
+		if err != nil {
+			a.Logger.Debugf("ignoring %s: %s", nested, err)
+			continue
+		}
+		artifacts = append(artifacts, a.resolveJar(n, nested)...)
+	}
+
+	return artifacts
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testArtifact(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	it("formats coordinates", func() {
+		Expect(distzip.Artifact{GroupID: "com.example", ArtifactID: "alpha", Version: "1.0"}.String()).To(Equal("com.example:alpha:1.0"))
+		Expect(distzip.Artifact{ArtifactID: "alpha", Version: "1.0"}.String()).To(Equal("alpha:1.0"))
+	})
+
+	context("ReadArtifact", func() {
+		it("reads pom.properties", func() {
+			writeJar(t, filepath.Join(path, "guava-33.0-jre.jar"), map[string]string{
+				"META-INF/maven/com.google.guava/guava/pom.properties": "#Generated\nartifactId=guava\ngroupId=com.google.guava\nversion=33.0-jre\n",
+			})
+
+			Expect(distzip.ReadArtifact(filepath.Join(path, "guava-33.0-jre.jar"))).To(Equal(distzip.Artifact{
+				Path:       filepath.Join(path, "guava-33.0-jre.jar"),
+				GroupID:    "com.google.guava",
+				ArtifactID: "guava",
+				Version:    "33.0-jre",
+			}))
+		})
+
+		it("prefers pom.properties matching the file name of shaded jars", func() {
+			writeJar(t, filepath.Join(path, "alpha-1.0.jar"), map[string]string{
+				"META-INF/maven/com.example/bravo/pom.properties": "artifactId=bravo\ngroupId=com.example\nversion=2.0\n",
+				"META-INF/maven/com.example/alpha/pom.properties": "artifactId=alpha\ngroupId=com.example\nversion=1.0\n",
+			})
+
+			Expect(distzip.ReadArtifact(filepath.Join(path, "alpha-1.0.jar"))).To(HaveField("ArtifactID", "alpha"))
+		})
+
+		it("parses the file name without pom.properties", func() {
+			writeJar(t, filepath.Join(path, "alpha-core-1.2.3-SNAPSHOT.jar"), map[string]string{})
+
+			Expect(distzip.ReadArtifact(filepath.Join(path, "alpha-core-1.2.3-SNAPSHOT.jar"))).To(Equal(distzip.Artifact{
+				Path:       filepath.Join(path, "alpha-core-1.2.3-SNAPSHOT.jar"),
+				ArtifactID: "alpha-core",
+				Version:    "1.2.3-SNAPSHOT",
+			}))
+		})
+	})
+
+	it("resolves artifacts in lib", func() {
+		writeJar(t, filepath.Join(path, "lib", "alpha-1.0.jar"), map[string]string{})
+		writeJar(t, filepath.Join(path, "lib", "bravo-2.0.jar"), map[string]string{})
+		Expect(os.WriteFile(filepath.Join(path, "lib", "charlie-3.0.jar"), []byte("not a jar"), 0644)).To(Succeed())
+
+		artifacts, err := distzip.ArtifactResolver{}.Resolve(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(artifacts).To(Equal([]distzip.Artifact{
+			{Path: filepath.Join(path, "lib", "alpha-1.0.jar"), ArtifactID: "alpha", Version: "1.0"},
+			{Path: filepath.Join(path, "lib", "bravo-2.0.jar"), ArtifactID: "bravo", Version: "2.0"},
+		}))
+	})
+
+	it("resolves artifacts below a directory", func() {
+		writeJar(t, filepath.Join(path, "alpha-1.0.jar"), map[string]string{})
+		writeJar(t, filepath.Join(path, "app", "lib", "bravo-2.0.jar"), map[string]string{})
+
+		artifacts, err := distzip.ArtifactResolver{}.ResolveTree(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(artifacts).To(Equal([]distzip.Artifact{
+			{Path: filepath.Join(path, "alpha-1.0.jar"), ArtifactID: "alpha", Version: "1.0"},
+			{Path: filepath.Join(path, "app", "lib", "bravo-2.0.jar"), ArtifactID: "bravo", Version: "2.0"},
+		}))
+	})
+
+	it("resolves artifacts nested in jars", func() {
+		nested := filepath.Join(t.TempDir(), "log4j-core-2.14.1.jar")
+		writeJar(t, nested, map[string]string{
+			"META-INF/maven/org.apache.logging.log4j/log4j-core/pom.properties": "groupId=org.apache.logging.log4j\nartifactId=log4j-core\nversion=2.14.1\n",
+		})
+		b, err := os.ReadFile(nested)
+		Expect(err).NotTo(HaveOccurred())
+
+		writeJar(t, filepath.Join(path, "app", "lib", "app-1.0.jar"), map[string]string{
+			"BOOT-INF/lib/log4j-core-2.14.1.jar": string(b),
+		})
+
+		artifacts, err := distzip.ArtifactResolver{}.ResolveTree(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		file := filepath.Join(path, "app", "lib", "app-1.0.jar") + "!/BOOT-INF/lib/log4j-core-2.14.1.jar"
+		Expect(artifacts).To(Equal([]distzip.Artifact{
+			{Path: filepath.Join(path, "app", "lib", "app-1.0.jar"), ArtifactID: "app", Version: "1.0"},
+			{Path: file, GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.14.1"},
+		}))
+
+		Expect(distzip.ReadArtifact(file)).To(Equal(artifacts[1]))
+	})
+}
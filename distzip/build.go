@@ -21,8 +21,11 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
-	"github.com/heroku/color"
 	"github.com/paketo-buildpacks/libpak/effect"
 	"github.com/paketo-buildpacks/libpak/sbom"
 
@@ -31,6 +34,12 @@ import (
 	"github.com/paketo-buildpacks/libpak/bard"
 )
 
+// java8 matches the values of $BP_JVM_VERSION selecting Java 8, whose JDWP agent does not accept a host in address.
+var java8 = regexp.MustCompile(`^(?:1\.)?8(?:\D|$)`)
+
+// jfrDuration matches the durations accepted by -XX:StartFlightRecording.
+var jfrDuration = regexp.MustCompile(`^\d+(?:ns|us|ms|s|m|h|d)$`)
+
 type Build struct {
 	Logger      bard.Logger
 	SBOMScanner sbom.SBOMScanner
@@ -44,16 +53,30 @@ func (b Build) Build(context libcnb.BuildContext) (libcnb.BuildResult, error) {
 		return libcnb.BuildResult{}, fmt.Errorf("unable to create configuration resolver\n%w", err)
 	}
 
+	strict := cr.ResolveBool("BP_DIST_ZIP_STRICT")
+
+	application, _ := cr.Resolve("BP_DIST_ZIP_APPLICATION")
 	sr := ScriptResolver{
 		ApplicationPath:       context.Application.Path,
 		ConfigurationResolver: cr,
 		Logger:                b.Logger,
+		Strict:                strict,
+		SelectLatest:          cr.ResolveBool("BP_DIST_ZIP_SELECT_LATEST"),
+		Application:           application,
 	}
 	s, ok, err := sr.Resolve()
 	if err != nil {
 		return libcnb.BuildResult{}, fmt.Errorf("unable to detect application scripts\n%w", err)
 	}
 
+	var l Launch
+	if !ok {
+		l, ok, err = JarResolver{ApplicationPath: context.Application.Path, Logger: b.Logger, Strict: strict}.Resolve()
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to detect runnable jars\n%w", err)
+		}
+	}
+
 	if !ok {
 		for _, entry := range context.Plan.Entries {
 			result.Unmet = append(result.Unmet, libcnb.UnmetPlanEntry{Name: entry.Name})
@@ -68,29 +91,360 @@ func (b Build) Build(context libcnb.BuildContext) (libcnb.BuildResult, error) {
 		return libcnb.BuildResult{}, fmt.Errorf("unable to create configuration resolver\n%w", err)
 	}
 
-	err = os.Chmod(s, 0755)
+	if cr.ResolveBool("BP_DIST_ZIP_HARDEN") && cr.ResolveBool("BP_LIVE_RELOAD_ENABLED") {
+		return libcnb.BuildResult{}, fmt.Errorf("$BP_DIST_ZIP_HARDEN cannot be combined with $BP_LIVE_RELOAD_ENABLED")
+	}
+
+	if s != "" {
+		b.Logger.Bodyf("Resolved application script %s from %s", s, sr.Location)
+
+		if len(sr.Superseded) > 0 {
+			b.Logger.Bodyf("Selected %s, superseding %s", filepath.Dir(filepath.Dir(s)), strings.Join(sr.Superseded, ", "))
+
+			if cr.ResolveBool("BP_DIST_ZIP_PRUNE_SUPERSEDED") {
+				for _, dir := range sr.Superseded {
+					b.Logger.Bodyf("Removing superseded %s", dir)
+					if err := os.RemoveAll(dir); err != nil {
+						return libcnb.BuildResult{}, fmt.Errorf("unable to remove %s\n%w", dir, err)
+					}
+				}
+			}
+		}
+
+		if err := os.Chmod(s, 0755); err != nil {
+			if err := warn(b.Logger, strict, ScriptNotExecutableError{Path: s, Err: err}); err != nil {
+				return libcnb.BuildResult{}, err
+			}
+		}
+
+		l, err = LaunchResolver{Logger: b.Logger}.Resolve(s)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to resolve launch description\n%w", err)
+		}
+	}
+	b.Logger.Bodyf("Using %s launcher %s", l.Kind, l.Launcher)
+
+	if flags := MemoryFlags(l.JVMOptions); len(flags) > 0 {
+		if cr.ResolveBool("BP_DIST_ZIP_STRIP_MEMORY_FLAGS") {
+			if l, err = (MemoryFlagStripper{Logger: b.Logger}).Strip(l); err != nil {
+				return libcnb.BuildResult{}, fmt.Errorf("unable to strip memory flags\n%w", err)
+			}
+		} else if err := warn(b.Logger, strict, MemoryFlagsError{Launcher: l.Launcher, Flags: flags}); err != nil {
+			return libcnb.BuildResult{}, err
+		}
+	}
+
+	if classpath, ok := cr.Resolve("BP_DIST_ZIP_CLASSPATH_APPEND"); ok && classpath != "" {
+		c := ClasspathAppender{ApplicationPath: context.Application.Path, Logger: b.Logger}
+		if l, err = c.Append(l, filepath.SplitList(classpath)); err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to append to classpath\n%w", err)
+		}
+	}
+
+	if cr.ResolveBool("BP_DIST_ZIP_VALIDATE_LAUNCH") {
+		problems, err := LaunchValidator{Logger: b.Logger}.Validate(l, context.Application.Path)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to validate launch description\n%w", err)
+		}
+		if len(problems) > 0 {
+			return libcnb.BuildResult{}, InvalidLaunchError{Launcher: l.Launcher, Problems: problems}
+		}
+	}
+
+	boot, ok, err := SpringBootResolver{Logger: b.Logger}.Resolve(l)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to resolve Spring Boot application\n%w", err)
+	}
+	if ok {
+		b.Logger.Bodyf("Spring Boot %s application %s, start class %s", boot.Version, boot.Jar, boot.StartClass)
+		result.Labels = append(result.Labels, boot.Labels()...)
+	}
+
+	restored, err := ExecutableRestorer{Path: l.Home}.Restore()
 	if err != nil {
-		b.Logger.Bodyf("%s Unable to make script executable\n%w", color.YellowString("WARNING:"), err.Error())
+		return libcnb.BuildResult{}, fmt.Errorf("unable to restore execute permissions\n%w", err)
+	}
+	if len(restored) > 0 {
+		b.Logger.Bodyf("Restored execute permissions of %d files", len(restored))
+		for _, r := range restored {
+			b.Logger.Bodyf("  %s", r)
+		}
+	}
+
+	arch := TargetArch()
+	mismatches, err := ArchitectureVerifier{ApplicationPath: context.Application.Path, Logger: b.Logger}.Verify(arch)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to verify native file architectures\n%w", err)
+	}
+	if len(mismatches) > 0 {
+		if err := warn(b.Logger, strict, ArchitectureMismatchError{Architecture: arch, Mismatches: mismatches}); err != nil {
+			return libcnb.BuildResult{}, err
+		}
+	}
+
+	directLaunch := cr.ResolveBool("BP_DIST_ZIP_DIRECT_LAUNCH") || l.Kind == LaunchKindJar
+
+	libraries, err := NativeLibraryResolver{Home: l.Home, Logger: b.Logger}.Resolve(arch)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to resolve native libraries\n%w", err)
+	}
+	if len(libraries) > 0 {
+		if directLaunch {
+			l.JVMOptions = append(l.JVMOptions, fmt.Sprintf("-Djava.library.path=%s", strings.Join(libraries, string(filepath.ListSeparator))))
+		}
+
+		nl := NewNativeLibraries(libraries, !directLaunch)
+		nl.Logger = b.Logger
+		result.Layers = append(result.Layers, nl)
+	}
+
+	h := libpak.NewHelperLayerContributor(context.Buildpack, "java-agents")
+	h.Logger = b.Logger
+	result.Layers = append(result.Layers, h)
+
+	var linter Linter
+	for name, level := range map[string]*LintLevel{
+		"BP_DIST_ZIP_LINT_DUPLICATES":     &linter.Duplicates,
+		"BP_DIST_ZIP_LINT_SNAPSHOTS":      &linter.Snapshots,
+		"BP_DIST_ZIP_LINT_TEST_ARTIFACTS": &linter.TestArtifacts,
+	} {
+		s, _ := cr.Resolve(name)
+		if *level, err = ParseLintLevel(s); err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to parse $%s\n%w", name, err)
+		}
+	}
+
+	artifacts, err := ArtifactResolver{Logger: b.Logger}.Resolve(l.Home)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to resolve artifacts\n%w", err)
+	}
+
+	warnings, failures := linter.Lint(artifacts)
+	if len(warnings) > 0 {
+		if err := warn(b.Logger, strict, LintError{Findings: warnings}); err != nil {
+			return libcnb.BuildResult{}, err
+		}
+	}
+	if len(failures) > 0 {
+		return libcnb.BuildResult{}, LintError{Findings: failures}
 	}
 
-	result.Processes = append(result.Processes,
-		libcnb.Process{Type: "dist-zip", Command: s},
-		libcnb.Process{Type: "task", Command: s},
-		libcnb.Process{Type: "web", Command: s, Default: true},
-	)
+	policyFile, _ := cr.Resolve("BP_DIST_ZIP_POLICY")
+	pr := PolicyResolver{ApplicationPath: context.Application.Path, Bindings: context.Platform.Bindings, Logger: b.Logger}
+	policy, policyFile, ok, err := pr.Resolve(policyFile)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to resolve policy\n%w", err)
+	}
+	if ok {
+		artifacts, err := ArtifactResolver{Logger: b.Logger}.ResolveTree(context.Application.Path)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to resolve artifacts\n%w", err)
+		}
+
+		b.Logger.Bodyf("Evaluating %d artifacts against policy %s", len(artifacts), policyFile)
+		violations, unverified := policy.Evaluate(artifacts)
+		if len(unverified) > 0 {
+			if err := warn(b.Logger, strict, UnverifiedArtifactsError{Policy: policyFile, Artifacts: unverified}); err != nil {
+				violations = append(violations, unverified...)
+			}
+		}
+		if len(violations) > 0 {
+			return libcnb.BuildResult{}, PolicyViolationError{Policy: policyFile, Violations: violations}
+		}
+	}
+
+	if cr.ResolveBool("BP_DIST_ZIP_LICENSE_REPORT") {
+		artifacts, err := ArtifactResolver{Logger: b.Logger}.ResolveTree(context.Application.Path)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to resolve artifacts\n%w", err)
+		}
+
+		report, err := NewLicenseReport(context.Application.Path, artifacts)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to create license report\n%w", err)
+		}
+
+		lc := NewLicenses(report)
+		lc.Logger = b.Logger
+		result.Layers = append(result.Layers, lc)
+		result.Labels = append(result.Labels, libcnb.Label{
+			Key:   LicensesLabel,
+			Value: filepath.Join(context.Layers.Path, lc.Name(), "licenses.json"),
+		})
+	}
+
+	var executables []string
+	if s != "" {
+		executables = append(executables, s)
+	}
+
+	procfile, ok, err := ProcfileResolver{Home: l.Home, Logger: b.Logger}.Resolve()
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to resolve Procfile\n%w", err)
+	}
+
+	if ok {
+		b.Logger.Bodyf("Using processes from %s", filepath.Join(l.Home, "Procfile"))
+		for _, p := range procfile {
+			executables = append(executables, p.Command)
+		}
+		result.Processes = append(result.Processes, procfile...)
+	} else {
+		command, arguments, direct := l.Launcher, []string(nil), false
+		if directLaunch {
+			if !l.Direct() {
+				return libcnb.BuildResult{}, fmt.Errorf("unable to launch %s directly, no main class or jar found", l.Launcher)
+			}
+			command, arguments, direct = "java", l.JavaArguments(), true
+		}
+
+		result.Processes = append(result.Processes,
+			libcnb.Process{Type: "dist-zip", Command: command, Arguments: arguments, Direct: direct},
+			libcnb.Process{Type: "task", Command: command, Arguments: arguments, Direct: direct},
+			libcnb.Process{Type: "web", Command: command, Arguments: arguments, Direct: direct, Default: true},
+		)
+	}
+
+	runImage := NewRunImage(context.StackID)
+	var checked []string
+	for _, p := range result.Processes {
+		if p.Direct || !filepath.IsAbs(p.Command) || slices.Contains(checked, p.Command) {
+			continue
+		}
+		checked = append(checked, p.Command)
+
+		shebang, ok, err := ReadShebang(p.Command)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to read shebang\n%w", err)
+		}
+		if !ok {
+			continue
+		}
+
+		if found, known := runImage.Provides(shebang.Program()); !known {
+			b.Logger.Debugf("unable to determine if run image %s contains %s required by %s", runImage, shebang.Program(), p.Command)
+		} else if !found {
+			e := InterpreterNotFoundError{
+				Launcher:     p.Command,
+				Shebang:      shebang,
+				RunImage:     runImage,
+				DirectLaunch: len(procfile) == 0 && p.Command == l.Launcher && l.Direct(),
+			}
+			if err := warn(b.Logger, strict, e); err != nil {
+				return libcnb.BuildResult{}, err
+			}
+		}
+	}
+
+	workingDirectory := l.Home
+	if s, ok := cr.Resolve("BP_DIST_ZIP_WORKING_DIRECTORY"); ok && s != "" {
+		workingDirectory = s
+		if !filepath.IsAbs(s) {
+			workingDirectory = filepath.Join(context.Application.Path, s)
+			rel, err := filepath.Rel(context.Application.Path, workingDirectory)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return libcnb.BuildResult{}, fmt.Errorf("invalid $BP_DIST_ZIP_WORKING_DIRECTORY %s, expected a directory in the application", s)
+			}
+			if info, err := os.Stat(workingDirectory); err != nil || !info.IsDir() {
+				return libcnb.BuildResult{}, fmt.Errorf("invalid $BP_DIST_ZIP_WORKING_DIRECTORY %s, expected a directory in the application", s)
+			}
+		}
+	}
+	b.Logger.Bodyf("Processes start in %s", workingDirectory)
+	for i := range result.Processes {
+		result.Processes[i].WorkingDirectory = workingDirectory
+	}
+
+	if cr.ResolveBool("BP_DIST_ZIP_REPRODUCIBLE") {
+		t, err := SourceDateEpoch()
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to resolve source date epoch\n%w", err)
+		}
+
+		n := Normalizer{ApplicationPath: context.Application.Path, Logger: b.Logger, Executables: executables}
+		if err := n.Normalize(t); err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to normalize application files\n%w", err)
+		}
+	}
+
+	if cr.ResolveBool("BP_DIST_ZIP_HARDEN") {
+		h := Hardener{ApplicationPath: context.Application.Path, Logger: b.Logger, Executables: executables}
+		if err := h.Harden(); err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to harden application permissions\n%w", err)
+		}
+	}
+
+	if cr.ResolveBool("BP_DIST_ZIP_DEBUG_ENABLED") {
+		port := "8000"
+		if s, ok := cr.Resolve("BP_DIST_ZIP_DEBUG_PORT"); ok && s != "" {
+			port = s
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("invalid $BP_DIST_ZIP_DEBUG_PORT %s\n%w", port, err)
+		}
+
+		suspend := "n"
+		if cr.ResolveBool("BP_DIST_ZIP_DEBUG_SUSPEND") {
+			suspend = "y"
+		}
+
+		address := fmt.Sprintf("*:%s", port)
+		if v, _ := cr.Resolve("BP_JVM_VERSION"); java8.MatchString(v) {
+			address = port
+		}
+
+		b.addOptionsProcess(&result, "debug", l, []string{
+			fmt.Sprintf("-agentlib:jdwp=transport=dt_socket,server=y,address=%s,suspend=%s", address, suspend),
+		}, cr.ResolveBool("BP_LIVE_RELOAD_ENABLED"))
+	}
+
+	if cr.ResolveBool("BP_DIST_ZIP_PROFILE_ENABLED") {
+		recording := []string{"settings=profile"}
+		if s, ok := cr.Resolve("BP_DIST_ZIP_PROFILE_SETTINGS"); ok && s != "" {
+			recording[0] = fmt.Sprintf("settings=%s", s)
+		}
+
+		if s, ok := cr.Resolve("BP_DIST_ZIP_PROFILE_DURATION"); ok && s != "" {
+			if !jfrDuration.MatchString(s) {
+				return libcnb.BuildResult{}, fmt.Errorf("invalid $BP_DIST_ZIP_PROFILE_DURATION %s, expected a duration such as 30s, 10m or 1h", s)
+			}
+			recording = append(recording, fmt.Sprintf("duration=%s", s))
+		}
+
+		output := "/tmp/dist-zip.jfr"
+		if s, ok := cr.Resolve("BP_DIST_ZIP_PROFILE_OUTPUT"); ok && s != "" {
+			output = s
+		}
+		if !filepath.IsAbs(output) {
+			return libcnb.BuildResult{}, fmt.Errorf("invalid $BP_DIST_ZIP_PROFILE_OUTPUT %s, expected an absolute path", output)
+		}
+		if rel, err := filepath.Rel(context.Application.Path, output); err == nil && !strings.HasPrefix(rel, "..") && cr.ResolveBool("BP_DIST_ZIP_HARDEN") {
+			return libcnb.BuildResult{}, fmt.Errorf("$BP_DIST_ZIP_PROFILE_OUTPUT %s is not writable in the hardened application", output)
+		}
+		recording = append(recording, fmt.Sprintf("filename=%s", output), "dumponexit=true")
+
+		b.addOptionsProcess(&result, "profile", l, []string{
+			fmt.Sprintf("-XX:StartFlightRecording=%s", strings.Join(recording, ",")),
+		}, cr.ResolveBool("BP_LIVE_RELOAD_ENABLED"))
+	}
 
 	if cr.ResolveBool("BP_LIVE_RELOAD_ENABLED") {
+		var reload libcnb.Process
 		for i := 0; i < len(result.Processes); i++ {
+			if result.Processes[i].Default {
+				reload = result.Processes[i]
+			}
 			result.Processes[i].Default = false
 		}
 
 		result.Processes = append(result.Processes,
 			libcnb.Process{
-				Type:      "reload",
-				Command:   "watchexec",
-				Arguments: []string{"-r", s},
-				Direct:    false,
-				Default:   true,
+				Type:             "reload",
+				Command:          "watchexec",
+				Arguments:        append([]string{"-r", reload.Command}, reload.Arguments...),
+				Direct:           false,
+				Default:          true,
+				WorkingDirectory: reload.WorkingDirectory,
 			},
 		)
 
@@ -118,3 +472,32 @@ func (b Build) Build(context libcnb.BuildContext) (libcnb.BuildResult, error) {
 
 	return result, nil
 }
+
+// addOptionsProcess adds a process of type processType that runs the default process with additional JVM options,
+// wrapped in watchexec if reload is true.
+func (b Build) addOptionsProcess(result *libcnb.BuildResult, processType string, l Launch, options []string, reload bool) {
+	base := result.Processes[0]
+	for _, p := range result.Processes {
+		if p.Default {
+			base = p
+		}
+	}
+
+	p, po, ok := NewOptionsProcess(base, processType, l.OptionsVariable(), options)
+	if ok {
+		po.Logger = b.Logger
+		result.Layers = append(result.Layers, po)
+	}
+
+	if reload {
+		p = libcnb.Process{
+			Type:             p.Type,
+			Command:          "watchexec",
+			Arguments:        append([]string{"-r", p.Command}, p.Arguments...),
+			WorkingDirectory: p.WorkingDirectory,
+		}
+	}
+
+	b.Logger.Bodyf("Contributing %s process type with %s", processType, strings.Join(options, " "))
+	result.Processes = append(result.Processes, p)
+}
@@ -17,12 +17,16 @@
 package distzip_test
 
 import (
+	"bytes"
+	"debug/elf"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/paketo-buildpacks/libpak"
+	"github.com/paketo-buildpacks/libpak/bard"
 	"github.com/paketo-buildpacks/libpak/sbom/mocks"
 
 	"github.com/buildpacks/libcnb"
@@ -37,6 +41,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect      = NewWithT(t).Expect
 		sbomScanner mocks.SBOMScanner
 		ctx         libcnb.BuildContext
+		home        string
 	)
 
 	it.Before(func() {
@@ -45,6 +50,8 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		ctx.Application.Path = t.TempDir()
 		Expect(err).NotTo(HaveOccurred())
 
+		home = filepath.Join(ctx.Application.Path, "app")
+
 		ctx.Buildpack.Metadata = map[string]interface{}{
 			"configurations": []map[string]interface{}{
 				{
@@ -73,13 +80,79 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(result.Processes).To(ContainElements(
-				libcnb.Process{Type: "dist-zip", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script")},
-				libcnb.Process{Type: "task", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script")},
-				libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), Default: true},
+				libcnb.Process{Type: "dist-zip", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), WorkingDirectory: home},
+				libcnb.Process{Type: "task", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), WorkingDirectory: home},
+				libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), Default: true, WorkingDirectory: home},
 			))
 			sbomScanner.AssertCalled(t, "ScanLaunch", ctx.Application.Path, libcnb.SyftJSON, libcnb.CycloneDXJSON)
 		})
 
+		it("contributes java-agents helper", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(1))
+			Expect(result.Layers[0].Name()).To(Equal("helper"))
+			Expect(result.Layers[0].(libpak.HelperLayerContributor).Names).To(Equal([]string{"java-agents"}))
+		})
+
+		context("$BP_DIST_ZIP_REPRODUCIBLE is true", func() {
+			it.Before(func() {
+				t.Setenv("BP_DIST_ZIP_REPRODUCIBLE", "true")
+				t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+				Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "lib"), 0700)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), []byte{}, 0775)).To(Succeed())
+			})
+
+			it("normalizes application files", func() {
+				_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				info, err := os.Stat(filepath.Join(ctx.Application.Path, "app", "bin", "test-script"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal("-rwxr-xr-x"))
+				Expect(info.ModTime().Unix()).To(Equal(int64(1700000000)))
+
+				info, err = os.Stat(filepath.Join(ctx.Application.Path, "app", "lib"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal("drwxr-xr-x"))
+
+				info, err = os.Stat(filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal("-rw-r--r--"))
+				Expect(info.ModTime().Unix()).To(Equal(int64(1700000000)))
+			})
+		})
+
+		context("$BP_DIST_ZIP_HARDEN is true", func() {
+			it.Before(func() {
+				t.Setenv("BP_DIST_ZIP_HARDEN", "true")
+
+				Expect(os.Chmod(filepath.Join(ctx.Application.Path, "app", "bin"), 0777)).To(Succeed())
+			})
+
+			it("hardens application permissions", func() {
+				_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				info, err := os.Stat(filepath.Join(ctx.Application.Path, "app", "bin"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal("drwxr-xr-x"))
+
+				info, err = os.Stat(filepath.Join(ctx.Application.Path, "app", "bin", "test-script"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal("-rwxr-xr-x"))
+			})
+
+			it("fails if $BP_LIVE_RELOAD_ENABLED is true", func() {
+				t.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+
+				_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).To(MatchError("$BP_DIST_ZIP_HARDEN cannot be combined with $BP_LIVE_RELOAD_ENABLED"))
+			})
+		})
+
 		context("$BP_LIVE_RELOAD_ENABLED is true", func() {
 			it.Before(func() {
 				t.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
@@ -90,10 +163,10 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(result.Processes).To(ContainElements(
-					libcnb.Process{Type: "dist-zip", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script")},
-					libcnb.Process{Type: "task", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script")},
-					libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script")},
-					libcnb.Process{Type: "reload", Command: "watchexec", Arguments: []string{"-r", filepath.Join(ctx.Application.Path, "app", "bin", "test-script")}, Default: true},
+					libcnb.Process{Type: "dist-zip", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), WorkingDirectory: home},
+					libcnb.Process{Type: "task", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), WorkingDirectory: home},
+					libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), WorkingDirectory: home},
+					libcnb.Process{Type: "reload", Command: "watchexec", Arguments: []string{"-r", filepath.Join(ctx.Application.Path, "app", "bin", "test-script")}, Default: true, WorkingDirectory: home},
 				))
 				sbomScanner.AssertCalled(t, "ScanLaunch", ctx.Application.Path, libcnb.SyftJSON, libcnb.CycloneDXJSON)
 			})
@@ -128,6 +201,311 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("$BP_DIST_ZIP_WORKING_DIRECTORY is set", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "conf"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), []byte{}, 0755)).To(Succeed())
+		})
+
+		it("starts processes in a directory of the application", func() {
+			t.Setenv("BP_DIST_ZIP_WORKING_DIRECTORY", "app/conf")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, p := range result.Processes {
+				Expect(p.WorkingDirectory).To(Equal(filepath.Join(ctx.Application.Path, "app", "conf")))
+			}
+		})
+
+		it("starts processes in an absolute directory", func() {
+			t.Setenv("BP_DIST_ZIP_WORKING_DIRECTORY", "/tmp")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, p := range result.Processes {
+				Expect(p.WorkingDirectory).To(Equal("/tmp"))
+			}
+		})
+
+		it("fails for a relative directory outside of the application", func() {
+			t.Setenv("BP_DIST_ZIP_WORKING_DIRECTORY", "../../..")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError("invalid $BP_DIST_ZIP_WORKING_DIRECTORY ../../.., expected a directory in the application"))
+		})
+
+		it("fails for a directory that does not exist", func() {
+			t.Setenv("BP_DIST_ZIP_WORKING_DIRECTORY", "app/missing")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError("invalid $BP_DIST_ZIP_WORKING_DIRECTORY app/missing, expected a directory in the application"))
+		})
+	})
+
+	context("run image does not contain the script interpreter", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			ctx.StackID = "io.buildpacks.stacks.jammy.tiny"
+
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "app")
+			Expect(os.MkdirAll(filepath.Dir(scriptPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(scriptPath, []byte(`#!/usr/bin/env bash
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -classpath "$CLASSPATH" com.example.Main "$@"
+`), 0755)).To(Succeed())
+		})
+
+		it("warns and suggests direct launch", func() {
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring(fmt.Sprintf(
+				"launcher %s requires bash from #!/usr/bin/env bash, which run image io.buildpacks.stacks.jammy.tiny does not contain", scriptPath)))
+			Expect(buf.String()).To(ContainSubstring("set `$BP_DIST_ZIP_DIRECT_LAUNCH` to start java directly"))
+		})
+
+		it("fails when strict", func() {
+			t.Setenv("BP_DIST_ZIP_STRICT", "true")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(distzip.InterpreterNotFoundError{
+				Launcher:     scriptPath,
+				Shebang:      distzip.Shebang{Interpreter: "/usr/bin/env", Arguments: []string{"bash"}},
+				RunImage:     distzip.RunImage{StackID: "io.buildpacks.stacks.jammy.tiny"},
+				DirectLaunch: true,
+			}))
+		})
+
+		it("does not warn when launching directly", func() {
+			t.Setenv("BP_DIST_ZIP_DIRECT_LAUNCH", "true")
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).NotTo(ContainSubstring("does not contain"))
+		})
+
+		it("does not warn when the run image contains the interpreter", func() {
+			ctx.StackID = "io.buildpacks.stacks.jammy"
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).NotTo(ContainSubstring("does not contain"))
+		})
+	})
+
+	context("$BP_DIST_ZIP_DIRECT_LAUNCH is true", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_DIRECT_LAUNCH", "true")
+
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "test-script")
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+		})
+
+		it("contributes processes that start java directly", func() {
+			Expect(os.WriteFile(scriptPath, []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -classpath "$CLASSPATH" com.example.Main "$@"
+`), 0755)).To(Succeed())
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			args := []string{"-cp", filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), "com.example.Main"}
+			Expect(result.Processes).To(ContainElements(
+				libcnb.Process{Type: "dist-zip", Command: "java", Arguments: args, Direct: true, WorkingDirectory: home},
+				libcnb.Process{Type: "task", Command: "java", Arguments: args, Direct: true, WorkingDirectory: home},
+				libcnb.Process{Type: "web", Command: "java", Arguments: args, Direct: true, Default: true, WorkingDirectory: home},
+			))
+		})
+
+		it("fails if the script cannot be described", func() {
+			Expect(os.WriteFile(scriptPath, []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("no main class or jar found")))
+		})
+	})
+
+	context("jpackage app-image", func() {
+		it.Before(func() {
+			writeELF(t, filepath.Join(ctx.Application.Path, "app", "bin", "app"), elf.EM_AARCH64)
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "lib", "app"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "lib", "app", "app.cfg"), []byte(`[Application]
+app.classpath=$APPDIR/app.jar
+app.mainclass=com.example.Main
+`), 0644)).To(Succeed())
+		})
+
+		it("warns if the launcher does not match the target architecture", func() {
+			t.Setenv("CNB_TARGET_ARCH", "amd64")
+			buf := &bytes.Buffer{}
+
+			result, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(
+				libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "app", "bin", "app"), Default: true, WorkingDirectory: home},
+			))
+			Expect(buf.String()).To(ContainSubstring("Using jpackage launcher"))
+			Expect(buf.String()).To(ContainSubstring("native files are not built for the target architecture amd64:"))
+			Expect(buf.String()).To(ContainSubstring("app/bin/app (arm64)"))
+		})
+	})
+
+	context("native libraries", func() {
+		it.Before(func() {
+			t.Setenv("CNB_TARGET_ARCH", "arm64")
+
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), []byte{}, 0755)).To(Succeed())
+			writeELF(t, filepath.Join(ctx.Application.Path, "app", "lib", "libalpha.so"), elf.EM_X86_64)
+			writeELF(t, filepath.Join(ctx.Application.Path, "app", "lib", "libbravo.so"), elf.EM_AARCH64)
+		})
+
+		it("warns about libraries built for the wrong architecture", func() {
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring("native files are not built for the target architecture arm64:"))
+			Expect(buf.String()).To(ContainSubstring("app/lib/libalpha.so (amd64)"))
+			Expect(buf.String()).NotTo(ContainSubstring("libbravo.so"))
+		})
+
+		context("$BP_DIST_ZIP_STRICT is true", func() {
+			it.Before(func() {
+				t.Setenv("BP_DIST_ZIP_STRICT", "true")
+			})
+
+			it("fails the build", func() {
+				_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).To(MatchError(ContainSubstring("app/lib/libalpha.so (amd64)")))
+			})
+		})
+	})
+
+	context("native library directories", func() {
+		it.Before(func() {
+			t.Setenv("CNB_TARGET_ARCH", "arm64")
+
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -classpath "$CLASSPATH" com.example.Main "$@"
+`), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "lib", "native", "linux-aarch64"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "lib", "native", "linux-x86_64"), 0755)).To(Succeed())
+		})
+
+		it("contributes native libraries for the target architecture", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(2))
+			Expect(result.Layers[0].Name()).To(Equal("native-libraries"))
+			Expect(result.Layers[0].(distzip.NativeLibraries).Paths).To(Equal([]string{
+				filepath.Join(ctx.Application.Path, "app", "lib", "native", "linux-aarch64"),
+			}))
+			Expect(result.Layers[0].(distzip.NativeLibraries).JavaToolOptions).To(BeTrue())
+		})
+
+		it("adds java.library.path to direct launch arguments", func() {
+			t.Setenv("BP_DIST_ZIP_DIRECT_LAUNCH", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers[0].(distzip.NativeLibraries).JavaToolOptions).To(BeFalse())
+			Expect(result.Processes).To(ContainElement(libcnb.Process{
+				Type:    "web",
+				Command: "java",
+				Arguments: []string{
+					"-Djava.library.path=" + filepath.Join(ctx.Application.Path, "app", "lib", "native", "linux-aarch64"),
+					"-cp", filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"),
+					"com.example.Main",
+				},
+				Direct:           true,
+				Default:          true,
+				WorkingDirectory: home,
+			}))
+		})
+	})
+
+	context("several applications exist", func() {
+		it.Before(func() {
+			for _, dir := range []string{"service-a", "service-b"} {
+				Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, dir, "bin"), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(ctx.Application.Path, dir, "bin", dir), []byte{}, 0755)).To(Succeed())
+			}
+		})
+
+		it("contributes processes for the selected application", func() {
+			t.Setenv("BP_DIST_ZIP_APPLICATION", "service-b")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(
+				libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "service-b", "bin", "service-b"), Default: true, WorkingDirectory: filepath.Join(ctx.Application.Path, "service-b")},
+			))
+		})
+
+		it("fails for an unknown application", func() {
+			t.Setenv("BP_DIST_ZIP_APPLICATION", "service-c")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("no application named service-c, found service-a, service-b")))
+		})
+	})
+
+	context("several versions of the distribution exist", func() {
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_SELECT_LATEST", "true")
+
+			for _, dir := range []string{"myapp-1.4.0", "myapp-1.5.0"} {
+				Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, dir, "bin"), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(ctx.Application.Path, dir, "bin", "myapp"), []byte{}, 0755)).To(Succeed())
+			}
+		})
+
+		it("contributes processes for the highest version", func() {
+			buf := &bytes.Buffer{}
+
+			result, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(
+				libcnb.Process{Type: "web", Command: filepath.Join(ctx.Application.Path, "myapp-1.5.0", "bin", "myapp"), Default: true, WorkingDirectory: filepath.Join(ctx.Application.Path, "myapp-1.5.0")},
+			))
+			Expect(buf.String()).To(ContainSubstring(fmt.Sprintf("Selected %s, superseding %s",
+				filepath.Join(ctx.Application.Path, "myapp-1.5.0"), filepath.Join(ctx.Application.Path, "myapp-1.4.0"))))
+			Expect(filepath.Join(ctx.Application.Path, "myapp-1.4.0")).To(BeADirectory())
+		})
+
+		it("removes superseded versions", func() {
+			t.Setenv("BP_DIST_ZIP_PRUNE_SUPERSEDED", "true")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(filepath.Join(ctx.Application.Path, "myapp-1.4.0")).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(ctx.Application.Path, "myapp-1.5.0")).To(BeADirectory())
+		})
+	})
+
 	context("DistZip exists but isn't executable", func() {
 		var scriptPath string
 
@@ -142,9 +520,9 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(result.Processes).To(ContainElements(
-				libcnb.Process{Type: "dist-zip", Command: scriptPath},
-				libcnb.Process{Type: "task", Command: scriptPath},
-				libcnb.Process{Type: "web", Command: scriptPath, Default: true},
+				libcnb.Process{Type: "dist-zip", Command: scriptPath, WorkingDirectory: home},
+				libcnb.Process{Type: "task", Command: scriptPath, WorkingDirectory: home},
+				libcnb.Process{Type: "web", Command: scriptPath, Default: true, WorkingDirectory: home},
 			))
 			sbomScanner.AssertCalled(t, "ScanLaunch", ctx.Application.Path, libcnb.SyftJSON, libcnb.CycloneDXJSON)
 
@@ -154,6 +532,558 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("DistZip contains a Procfile", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "test-script")
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(scriptPath, []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "Procfile"), []byte(`web: bin/test-script server
+worker: bin/test-script worker
+`), 0644)).To(Succeed())
+		})
+
+		it("contributes Procfile processes", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(Equal([]libcnb.Process{
+				{Type: "web", Command: scriptPath, Arguments: []string{"server"}, Default: true, WorkingDirectory: home},
+				{Type: "worker", Command: scriptPath, Arguments: []string{"worker"}, WorkingDirectory: home},
+			}))
+		})
+
+		it("contributes Procfile processes running commands from $PATH", func() {
+			ctx.StackID = "io.buildpacks.stacks.jammy.tiny"
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "Procfile"), []byte("web: java -jar lib/app.jar\n"), 0644)).To(Succeed())
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(Equal([]libcnb.Process{
+				{Type: "web", Command: "java", Arguments: []string{"-jar", "lib/app.jar"}, Default: true, WorkingDirectory: home},
+			}))
+		})
+
+		it("reloads the default Procfile process", func() {
+			t.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(
+				libcnb.Process{Type: "reload", Command: "watchexec", Arguments: []string{"-r", scriptPath, "server"}, Default: true, WorkingDirectory: home},
+			))
+		})
+	})
+
+	context("DistZip contains launchers that aren't executable", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "libexec"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "test-script"), []byte("#!/bin/sh\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "libexec", "helper"), []byte("#!/bin/sh\n"), 0644)).To(Succeed())
+		})
+
+		it("restores execute permissions in the distribution", func() {
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := os.Stat(filepath.Join(ctx.Application.Path, "app", "libexec", "helper"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().String()).To(Equal("-rwxr-xr-x"))
+			Expect(buf.String()).To(ContainSubstring("Restored execute permissions of 1 files"))
+			Expect(buf.String()).To(ContainSubstring(filepath.Join(ctx.Application.Path, "app", "libexec", "helper")))
+		})
+	})
+
+	context("$BP_DIST_ZIP_DEBUG_ENABLED is true", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_DEBUG_ENABLED", "true")
+
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "app")
+			Expect(os.MkdirAll(filepath.Dir(scriptPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(scriptPath, []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+eval set -- $DEFAULT_JVM_OPTS $JAVA_OPTS $APP_OPTS -classpath "\"$CLASSPATH\"" com.example.Main "$APP_ARGS"
+`), 0755)).To(Succeed())
+		})
+
+		it("contributes debug process type", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(libcnb.Process{Type: "debug", Command: scriptPath, WorkingDirectory: home}))
+			Expect(result.Layers).To(ContainElement(distzip.NewProcessOptions("debug", "APP_OPTS", []string{
+				"-agentlib:jdwp=transport=dt_socket,server=y,address=*:8000,suspend=n",
+			})))
+		})
+
+		it("omits the host from the address for Java 8", func() {
+			for _, version := range []string{"8", "1.8", "8.0.412"} {
+				t.Setenv("BP_JVM_VERSION", version)
+
+				result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Layers).To(ContainElement(distzip.NewProcessOptions("debug", "APP_OPTS", []string{
+					"-agentlib:jdwp=transport=dt_socket,server=y,address=8000,suspend=n",
+				})))
+			}
+		})
+
+		it("includes the host in the address for Java 11 and later", func() {
+			t.Setenv("BP_JVM_VERSION", "11")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(ContainElement(distzip.NewProcessOptions("debug", "APP_OPTS", []string{
+				"-agentlib:jdwp=transport=dt_socket,server=y,address=*:8000,suspend=n",
+			})))
+		})
+
+		it("configures port and suspend", func() {
+			t.Setenv("BP_DIST_ZIP_DEBUG_PORT", "5005")
+			t.Setenv("BP_DIST_ZIP_DEBUG_SUSPEND", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(ContainElement(distzip.NewProcessOptions("debug", "APP_OPTS", []string{
+				"-agentlib:jdwp=transport=dt_socket,server=y,address=*:5005,suspend=y",
+			})))
+		})
+
+		it("fails with an invalid port", func() {
+			t.Setenv("BP_DIST_ZIP_DEBUG_PORT", "alpha")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("invalid $BP_DIST_ZIP_DEBUG_PORT alpha")))
+		})
+
+		it("passes options to java when launching directly", func() {
+			t.Setenv("BP_DIST_ZIP_DIRECT_LAUNCH", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(libcnb.Process{
+				Type:    "debug",
+				Command: "java",
+				Arguments: []string{
+					"-agentlib:jdwp=transport=dt_socket,server=y,address=*:8000,suspend=n",
+					"-cp", filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), "com.example.Main",
+				},
+				Direct:           true,
+				WorkingDirectory: home,
+			}))
+		})
+
+		it("reloads debug process type", func() {
+			t.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(libcnb.Process{Type: "debug", Command: "watchexec", Arguments: []string{"-r", scriptPath}, WorkingDirectory: home}))
+			Expect(result.Processes).To(ContainElement(libcnb.Process{Type: "reload", Command: "watchexec", Arguments: []string{"-r", scriptPath}, Default: true, WorkingDirectory: home}))
+		})
+	})
+
+	context("$BP_DIST_ZIP_PROFILE_ENABLED is true", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_PROFILE_ENABLED", "true")
+
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "app")
+			Expect(os.MkdirAll(filepath.Dir(scriptPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(scriptPath, []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+eval set -- $DEFAULT_JVM_OPTS $JAVA_OPTS $APP_OPTS -classpath "\"$CLASSPATH\"" com.example.Main "$APP_ARGS"
+`), 0755)).To(Succeed())
+		})
+
+		it("contributes profile process type", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(libcnb.Process{Type: "profile", Command: scriptPath, WorkingDirectory: home}))
+			Expect(result.Layers).To(ContainElement(distzip.NewProcessOptions("profile", "APP_OPTS", []string{
+				"-XX:StartFlightRecording=settings=profile,filename=/tmp/dist-zip.jfr,dumponexit=true",
+			})))
+		})
+
+		it("configures settings, duration and output", func() {
+			t.Setenv("BP_DIST_ZIP_PROFILE_SETTINGS", "default")
+			t.Setenv("BP_DIST_ZIP_PROFILE_DURATION", "10m")
+			t.Setenv("BP_DIST_ZIP_PROFILE_OUTPUT", "/recordings/app.jfr")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(ContainElement(distzip.NewProcessOptions("profile", "APP_OPTS", []string{
+				"-XX:StartFlightRecording=settings=default,duration=10m,filename=/recordings/app.jfr,dumponexit=true",
+			})))
+		})
+
+		it("fails with an invalid duration", func() {
+			t.Setenv("BP_DIST_ZIP_PROFILE_DURATION", "ten minutes")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("invalid $BP_DIST_ZIP_PROFILE_DURATION ten minutes")))
+		})
+
+		it("fails with a relative output", func() {
+			t.Setenv("BP_DIST_ZIP_PROFILE_OUTPUT", "app.jfr")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("invalid $BP_DIST_ZIP_PROFILE_OUTPUT app.jfr, expected an absolute path")))
+		})
+
+		it("fails with an output in the hardened application", func() {
+			t.Setenv("BP_DIST_ZIP_HARDEN", "true")
+			t.Setenv("BP_DIST_ZIP_PROFILE_OUTPUT", filepath.Join(ctx.Application.Path, "app.jfr"))
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("is not writable in the hardened application")))
+		})
+	})
+
+	context("$BP_DIST_ZIP_CLASSPATH_APPEND is set", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_CLASSPATH_APPEND", "drivers/postgresql.jar:/layers/agent/agent.jar")
+
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "app")
+			Expect(os.MkdirAll(filepath.Dir(scriptPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(scriptPath, []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -classpath "$CLASSPATH" com.example.Main "$@"
+`), 0755)).To(Succeed())
+		})
+
+		it("appends entries to the script CLASSPATH", func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "drivers"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "drivers", "postgresql.jar"), []byte{}, 0644)).To(Succeed())
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.ReadFile(scriptPath)).To(ContainSubstring(fmt.Sprintf("CLASSPATH=$APP_HOME/lib/app.jar:%s:/layers/agent/agent.jar\n",
+				filepath.Join(ctx.Application.Path, "drivers", "postgresql.jar"))))
+		})
+
+		it("fails if an entry in the application does not exist", func() {
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("classpath entry %s does not exist",
+				filepath.Join(ctx.Application.Path, "drivers", "postgresql.jar"))))
+		})
+	})
+
+	context("lib contains jars that violate lint rules", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "app"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "core-1.0-SNAPSHOT.jar"), map[string]string{})
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "junit-4.13.2.jar"), map[string]string{
+				"META-INF/maven/junit/junit/pom.properties": "groupId=junit\nartifactId=junit\nversion=4.13.2\n",
+			})
+		})
+
+		it("warns about findings", func() {
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring("the application classpath violates lint rules:"))
+			Expect(buf.String()).To(ContainSubstring("core:1.0-SNAPSHOT is a snapshot version (core-1.0-SNAPSHOT.jar)"))
+			Expect(buf.String()).To(ContainSubstring("junit:junit:4.13.2 is a test-only artifact (junit-4.13.2.jar)"))
+		})
+
+		it("fails on findings of rules at error level", func() {
+			t.Setenv("BP_DIST_ZIP_LINT_TEST_ARTIFACTS", "error")
+			t.Setenv("BP_DIST_ZIP_LINT_SNAPSHOTS", "off")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(distzip.LintError{Findings: []string{"junit:junit:4.13.2 is a test-only artifact (junit-4.13.2.jar)"}}))
+		})
+
+		it("fails with an invalid level", func() {
+			t.Setenv("BP_DIST_ZIP_LINT_DUPLICATES", "fatal")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("unable to parse $BP_DIST_ZIP_LINT_DUPLICATES")))
+		})
+	})
+
+	context("$BP_DIST_ZIP_POLICY is set", func() {
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_POLICY", "policy.toml")
+
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "app"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "log4j-core-2.14.1.jar"), map[string]string{
+				"META-INF/maven/org.apache.logging.log4j/log4j-core/pom.properties": "groupId=org.apache.logging.log4j\nartifactId=log4j-core\nversion=2.14.1\n",
+			})
+		})
+
+		it("fails if the application contains denied artifacts", func() {
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "policy.toml"), []byte(`
+[[deny]]
+artifact = "org.apache.logging.log4j:log4j-core"
+versions = "< 2.17.1"
+`), 0644)).To(Succeed())
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("the application contains artifacts denied by policy %s", filepath.Join(ctx.Application.Path, "policy.toml"))))
+			Expect(err).To(MatchError(ContainSubstring("org.apache.logging.log4j:log4j-core  2.14.1")))
+		})
+
+		it("fails if a jar nested in the application contains denied artifacts", func() {
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "policy.toml"), []byte(`
+[[deny]]
+artifact = "org.apache.logging.log4j:log4j-core"
+versions = "< 2.17.1"
+`), 0644)).To(Succeed())
+
+			b, err := os.ReadFile(filepath.Join(ctx.Application.Path, "app", "lib", "log4j-core-2.14.1.jar"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.Remove(filepath.Join(ctx.Application.Path, "app", "lib", "log4j-core-2.14.1.jar"))).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), map[string]string{
+				"BOOT-INF/lib/log4j-core-2.14.1.jar": string(b),
+			})
+
+			_, err = distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("app.jar!/BOOT-INF/lib/log4j-core-2.14.1.jar")))
+		})
+
+		it("fails if a denied jar has no Maven metadata", func() {
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "policy.toml"), []byte(`
+[[deny]]
+artifact = "org.apache.logging.log4j:log4j-core"
+versions = "< 2.17.1"
+`), 0644)).To(Succeed())
+
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "log4j-core-2.14.1.jar"), map[string]string{})
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("log4j-core  2.14.1")))
+		})
+
+		context("artifact version is not comparable", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "policy.toml"), []byte(`
+[[deny]]
+artifact = "org.apache.logging.log4j:log4j-core"
+versions = "< 2.17.1"
+`), 0644)).To(Succeed())
+
+				writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "log4j-core-2.14.1.jar"), map[string]string{
+					"META-INF/maven/org.apache.logging.log4j/log4j-core/pom.properties": "groupId=org.apache.logging.log4j\nartifactId=log4j-core\nversion=custom\n",
+				})
+			})
+
+			it("warns", func() {
+				buf := &bytes.Buffer{}
+
+				_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buf.String()).To(ContainSubstring("org.apache.logging.log4j:log4j-core version custom is not comparable with < 2.17.1"))
+			})
+
+			it("fails when strict", func() {
+				t.Setenv("BP_DIST_ZIP_STRICT", "true")
+
+				_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+				Expect(err).To(MatchError(ContainSubstring("org.apache.logging.log4j:log4j-core  custom")))
+			})
+		})
+
+		it("passes if the application contains no denied artifacts", func() {
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "policy.toml"), []byte(`
+[[deny]]
+artifact = "org.apache.logging.log4j:log4j-core"
+versions = "< 2.12.0"
+`), 0644)).To(Succeed())
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	context("$BP_DIST_ZIP_LICENSE_REPORT is true", func() {
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_LICENSE_REPORT", "true")
+			ctx.Layers.Path = t.TempDir()
+
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "app"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "alpha-1.0.jar"), map[string]string{
+				"META-INF/LICENSE": "Apache License\nVersion 2.0, January 2004",
+			})
+		})
+
+		it("contributes license report", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(ContainElement(distzip.NewLicenses(distzip.LicenseReport{Artifacts: []distzip.LicensedArtifact{
+				{
+					Path:        filepath.Join("app", "lib", "alpha-1.0.jar"),
+					Coordinates: "alpha:1.0",
+					Licenses:    []distzip.License{{Name: "Apache-2.0", Source: "META-INF/LICENSE"}},
+				},
+			}})))
+			Expect(result.Labels).To(ContainElement(libcnb.Label{
+				Key:   "io.paketo.dist-zip.licenses",
+				Value: filepath.Join(ctx.Layers.Path, "licenses", "licenses.json"),
+			}))
+		})
+	})
+
+	context("$BP_DIST_ZIP_VALIDATE_LAUNCH is true", func() {
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_VALIDATE_LAUNCH", "true")
+
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "app"), []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app-1.0.jar:$APP_HOME/lib/foo-1.2.jar
+exec "$JAVACMD" -classpath "$CLASSPATH" com.example.Main "$@"
+`), 0755)).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "app-1.0.jar"), map[string]string{"com/example/Main.class": ""})
+		})
+
+		it("passes if the classpath and main class exist", func() {
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "foo-1.2.jar"), map[string]string{})
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("fails if a classpath entry does not exist", func() {
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "foo-1.3.jar"), map[string]string{})
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(distzip.InvalidLaunchError{
+				Launcher: filepath.Join(ctx.Application.Path, "app", "bin", "app"),
+				Problems: []string{fmt.Sprintf("classpath entry %s does not exist, found foo-1.3.jar", filepath.Join(ctx.Application.Path, "app", "lib", "foo-1.2.jar"))},
+			}))
+		})
+	})
+
+	context("DEFAULT_JVM_OPTS contains memory flags", func() {
+		var scriptPath string
+
+		it.Before(func() {
+			scriptPath = filepath.Join(ctx.Application.Path, "app", "bin", "app")
+			Expect(os.MkdirAll(filepath.Dir(scriptPath), 0755)).To(Succeed())
+			Expect(os.WriteFile(scriptPath, []byte(`#!/bin/sh
+DEFAULT_JVM_OPTS='"-Xmx2g" "-Dalpha=bravo"'
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -classpath "$CLASSPATH" com.example.Main "$@"
+`), 0755)).To(Succeed())
+		})
+
+		it("warns about memory flags", func() {
+			buf := &bytes.Buffer{}
+
+			_, err := distzip.Build{Logger: bard.NewLogger(buf), SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(ContainSubstring("sets -Xmx2g, overriding the memory calculator"))
+		})
+
+		it("fails in strict mode", func() {
+			t.Setenv("BP_DIST_ZIP_STRICT", "true")
+
+			_, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).To(MatchError(distzip.MemoryFlagsError{Launcher: scriptPath, Flags: []string{"-Xmx2g"}}))
+		})
+
+		it("strips memory flags", func() {
+			t.Setenv("BP_DIST_ZIP_STRIP_MEMORY_FLAGS", "true")
+			t.Setenv("BP_DIST_ZIP_DIRECT_LAUNCH", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.ReadFile(scriptPath)).To(ContainSubstring(`DEFAULT_JVM_OPTS='"-Dalpha=bravo"'`))
+			Expect(result.Processes).To(ContainElement(libcnb.Process{
+				Type:             "web",
+				Command:          "java",
+				Arguments:        []string{"-Dalpha=bravo", "-cp", filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), "com.example.Main"},
+				Direct:           true,
+				Default:          true,
+				WorkingDirectory: home,
+			}))
+		})
+	})
+
+	context("bootDistZip exists", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "app"), []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -jar "$CLASSPATH" "$@"
+`), 0755)).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), map[string]string{
+				"META-INF/MANIFEST.MF": "Main-Class: org.springframework.boot.loader.launch.JarLauncher\nStart-Class: com.example.Main\nSpring-Boot-Version: 3.2.1\n",
+			})
+		})
+
+		it("contributes Spring Boot labels", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Labels).To(Equal([]libcnb.Label{
+				{Key: "org.springframework.boot.version", Value: "3.2.1"},
+				{Key: "org.springframework.boot.start-class", Value: "com.example.Main"},
+			}))
+		})
+	})
+
+	context("runnable jar exists", func() {
+		var jarPath string
+
+		it.Before(func() {
+			jarPath = filepath.Join(ctx.Application.Path, "app", "lib", "app.jar")
+			writeJar(t, jarPath, map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Main\n"})
+		})
+
+		it("contributes processes that start the jar", func() {
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElements(
+				libcnb.Process{Type: "dist-zip", Command: "java", Arguments: []string{"-jar", jarPath}, Direct: true, WorkingDirectory: home},
+				libcnb.Process{Type: "task", Command: "java", Arguments: []string{"-jar", jarPath}, Direct: true, WorkingDirectory: home},
+				libcnb.Process{Type: "web", Command: "java", Arguments: []string{"-jar", jarPath}, Direct: true, Default: true, WorkingDirectory: home},
+			))
+			sbomScanner.AssertCalled(t, "ScanLaunch", ctx.Application.Path, libcnb.SyftJSON, libcnb.CycloneDXJSON)
+		})
+
+		it("contributes reloadable process type", func() {
+			t.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
+
+			result, err := distzip.Build{SBOMScanner: &sbomScanner}.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Processes).To(ContainElement(
+				libcnb.Process{Type: "reload", Command: "watchexec", Arguments: []string{"-r", "java", "-jar", jarPath}, Default: true, WorkingDirectory: home},
+			))
+		})
+	})
+
 	context("DistZip does not exists", func() {
 		it("passes plan entries to subsequent buildpacks", func() {
 			result, err := distzip.Build{}.Build(ctx)
@@ -163,5 +1093,12 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Expect(len(result.Unmet)).To(Equal(1))
 			Expect(result.Unmet[0].Name).To(Equal("jvm-application"))
 		})
+
+		it("fails if $BP_APPLICATION_SCRIPT is set", func() {
+			t.Setenv("BP_APPLICATION_SCRIPT", "bin/*")
+
+			_, err := distzip.Build{}.Build(ctx)
+			Expect(err).To(MatchError(ContainSubstring("no application script matches $BP_APPLICATION_SCRIPT bin/*")))
+		})
 	})
 }
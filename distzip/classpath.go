@@ -0,0 +1,123 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+type ClasspathAppender struct {
+	ApplicationPath string
+	Logger          bard.Logger
+}
+
+// Append adds entries to the classpath of the launch, rewriting the CLASSPATH of a start script or the app.classpath
+// of a jpackage configuration.  Relative entries are resolved against the application and entries within the
+// application must exist, entries outside of it, such as those contributed by other buildpacks, are not validated.
+func (c ClasspathAppender) Append(launch Launch, entries []string) (Launch, error) {
+	var resolved []string
+	for _, e := range entries {
+		if !filepath.IsAbs(e) {
+			e = filepath.Join(c.ApplicationPath, e)
+		}
+		e = filepath.Clean(e)
+
+		if rel, err := filepath.Rel(c.ApplicationPath, e); err != nil || strings.HasPrefix(rel, "..") {
+			c.Logger.Bodyf("Classpath entry %s is outside of the application and cannot be validated", e)
+		} else if _, err := os.Stat(strings.TrimSuffix(e, string(filepath.Separator)+"*")); os.IsNotExist(err) {
+			return Launch{}, fmt.Errorf("classpath entry %s does not exist", e)
+		} else if err != nil {
+			return Launch{}, fmt.Errorf("unable to stat %s\n%w", e, err)
+		}
+
+		resolved = append(resolved, e)
+	}
+
+	if len(resolved) == 0 {
+		return launch, nil
+	}
+	joined := strings.Join(resolved, string(filepath.ListSeparator))
+
+	switch launch.Kind {
+	case LaunchKindScript:
+		if launch.MainJar != "" {
+			return Launch{}, fmt.Errorf("unable to append to the classpath of %s, it starts %s with -jar", launch.Launcher, launch.MainJar)
+		}
+
+		found := false
+		err := rewriteLines(launch.Launcher, func(line string) (string, bool) {
+			if found || !strings.HasPrefix(line, "CLASSPATH=") {
+				return line, true
+			}
+			found = true
+
+			if n := len(line); n > len("CLASSPATH=") && (line[n-1] == '"' || line[n-1] == '\'') {
+				return fmt.Sprintf("%s%c%s%c", line[:n-1], filepath.ListSeparator, joined, line[n-1]), true
+			}
+			return fmt.Sprintf("%s%c%s", line, filepath.ListSeparator, joined), true
+		})
+		if err != nil {
+			return Launch{}, fmt.Errorf("unable to rewrite %s\n%w", launch.Launcher, err)
+		}
+		if !found {
+			return Launch{}, fmt.Errorf("unable to append to the classpath of %s, it does not set CLASSPATH", launch.Launcher)
+		}
+
+		c.Logger.Bodyf("Appended %s to CLASSPATH of %s", joined, launch.Launcher)
+
+	case LaunchKindJPackage:
+		cfg := filepath.Join(launch.Home, "lib", "app", fmt.Sprintf("%s.cfg", filepath.Base(launch.Launcher)))
+
+		found := false
+		err := rewriteLines(cfg, func(line string) (string, bool) {
+			switch {
+			case !found && strings.HasPrefix(line, "app.classpath="):
+				found = true
+				return fmt.Sprintf("%s%c%s", line, filepath.ListSeparator, joined), true
+			case !found && strings.TrimSpace(line) == "[Application]" && len(launch.ClassPath) == 0:
+				found = true
+				return fmt.Sprintf("%s\napp.classpath=%s", line, joined), true
+			default:
+				return line, true
+			}
+		})
+		if err != nil {
+			return Launch{}, fmt.Errorf("unable to rewrite %s\n%w", cfg, err)
+		}
+		if !found {
+			return Launch{}, fmt.Errorf("unable to append to the classpath of %s, it has no [Application] section", cfg)
+		}
+
+		c.Logger.Bodyf("Appended %s to app.classpath of %s", joined, cfg)
+
+	case LaunchKindJar:
+		// java -jar ignores the classpath, so start the main class instead
+		launch.MainJar = ""
+		c.Logger.Bodyf("Appended %s to the classpath of %s", joined, launch.Launcher)
+
+	default:
+		return Launch{}, fmt.Errorf("unable to append to the classpath of %s launcher %s", launch.Kind, launch.Launcher)
+	}
+
+	launch.ClassPath = append(append([]string{}, launch.ClassPath...), resolved...)
+	return launch, nil
+}
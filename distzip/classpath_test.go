@@ -0,0 +1,120 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testClasspath(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+		c    distzip.ClasspathAppender
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+		c.ApplicationPath = path
+
+		Expect(os.MkdirAll(filepath.Join(path, "drivers"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(path, "drivers", "alpha.jar"), []byte{}, 0644)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(path, "bin"), 0755)).To(Succeed())
+	})
+
+	it("appends to a quoted script CLASSPATH", func() {
+		script := filepath.Join(path, "bin", "app")
+		Expect(os.WriteFile(script, []byte("#!/bin/sh\nCLASSPATH=\"$APP_HOME/lib/app.jar\"\n"), 0755)).To(Succeed())
+
+		l, err := c.Append(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			Launcher:  script,
+			ClassPath: []string{filepath.Join(path, "lib", "app.jar")},
+			MainClass: "com.example.Main",
+		}, []string{"drivers/alpha.jar", "drivers/*"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(l.ClassPath).To(Equal([]string{
+			filepath.Join(path, "lib", "app.jar"),
+			filepath.Join(path, "drivers", "alpha.jar"),
+			filepath.Join(path, "drivers", "*"),
+		}))
+		Expect(os.ReadFile(script)).To(Equal([]byte("#!/bin/sh\nCLASSPATH=\"$APP_HOME/lib/app.jar:" +
+			filepath.Join(path, "drivers", "alpha.jar") + ":" + filepath.Join(path, "drivers", "*") + "\"\n")))
+	})
+
+	it("fails for a script without CLASSPATH", func() {
+		script := filepath.Join(path, "bin", "app")
+		Expect(os.WriteFile(script, []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+
+		_, err := c.Append(distzip.Launch{Kind: distzip.LaunchKindScript, Launcher: script}, []string{"drivers/alpha.jar"})
+		Expect(err).To(MatchError(ContainSubstring("it does not set CLASSPATH")))
+	})
+
+	it("fails for a script that starts a jar", func() {
+		_, err := c.Append(distzip.Launch{
+			Kind:     distzip.LaunchKindScript,
+			Launcher: filepath.Join(path, "bin", "app"),
+			MainJar:  filepath.Join(path, "lib", "app.jar"),
+		}, []string{"drivers/alpha.jar"})
+		Expect(err).To(MatchError(ContainSubstring("it starts %s with -jar", filepath.Join(path, "lib", "app.jar"))))
+	})
+
+	it("appends to app.classpath of a jpackage configuration", func() {
+		cfg := filepath.Join(path, "lib", "app", "app.cfg")
+		Expect(os.MkdirAll(filepath.Dir(cfg), 0755)).To(Succeed())
+		Expect(os.WriteFile(cfg, []byte("[Application]\napp.classpath=$APPDIR/app.jar\napp.mainclass=com.example.Main\n"), 0644)).To(Succeed())
+
+		_, err := c.Append(distzip.Launch{
+			Kind:      distzip.LaunchKindJPackage,
+			Launcher:  filepath.Join(path, "bin", "app"),
+			Home:      path,
+			ClassPath: []string{filepath.Join(path, "lib", "app", "app.jar")},
+		}, []string{"/layers/agent/agent.jar"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.ReadFile(cfg)).To(Equal([]byte("[Application]\napp.classpath=$APPDIR/app.jar:/layers/agent/agent.jar\napp.mainclass=com.example.Main\n")))
+	})
+
+	it("starts the main class of a runnable jar", func() {
+		l, err := c.Append(distzip.Launch{
+			Kind:      distzip.LaunchKindJar,
+			Launcher:  filepath.Join(path, "app.jar"),
+			ClassPath: []string{filepath.Join(path, "app.jar")},
+			MainClass: "com.example.Main",
+			MainJar:   filepath.Join(path, "app.jar"),
+		}, []string{"drivers/alpha.jar"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(l.JavaArguments()).To(Equal([]string{
+			"-cp", filepath.Join(path, "app.jar") + ":" + filepath.Join(path, "drivers", "alpha.jar"), "com.example.Main",
+		}))
+	})
+
+	it("fails for native launchers", func() {
+		_, err := c.Append(distzip.Launch{Kind: distzip.LaunchKindNative, Launcher: filepath.Join(path, "bin", "app")}, []string{"drivers/alpha.jar"})
+		Expect(err).To(MatchError(ContainSubstring("unable to append to the classpath of native launcher")))
+	})
+}
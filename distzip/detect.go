@@ -30,6 +30,7 @@ const (
 	PlanEntryJRE                   = "jre"
 	PlanEntryWatchexec             = "watchexec"
 	PlanEntrySyft                  = "syft"
+	PlanEntrySpringBootApplication = "spring-boot-application"
 )
 
 type Detect struct {
@@ -59,15 +60,48 @@ func (d Detect) Detect(context libcnb.DetectContext) (libcnb.DetectResult, error
 		return libcnb.DetectResult{}, fmt.Errorf("unable to create configuration resolver\n%w", err)
 	}
 
+	application, _ := cr.Resolve("BP_DIST_ZIP_APPLICATION")
 	sr := ScriptResolver{
 		ApplicationPath:       context.Application.Path,
 		ConfigurationResolver: cr,
 		Logger:                d.Logger,
+		Strict:                cr.ResolveBool("BP_DIST_ZIP_STRICT"),
+		SelectLatest:          cr.ResolveBool("BP_DIST_ZIP_SELECT_LATEST"),
+		Application:           application,
+		AllowMissing:          true,
 	}
-	if _, ok, err := sr.Resolve(); err != nil {
+	script, ok, err := sr.Resolve()
+	if err != nil {
 		return libcnb.DetectResult{}, fmt.Errorf("unable to resolve dist-zip scripts\n%w", err)
-	} else if ok {
+	}
+
+	var l Launch
+	if ok {
+		if l, err = (LaunchResolver{Logger: d.Logger}).Resolve(script); err != nil {
+			return libcnb.DetectResult{}, fmt.Errorf("unable to resolve launch description\n%w", err)
+		}
+	} else {
+		jr := JarResolver{ApplicationPath: context.Application.Path, Logger: d.Logger, Strict: sr.Strict}
+		if l, ok, err = jr.Resolve(); err != nil {
+			return libcnb.DetectResult{}, fmt.Errorf("unable to resolve runnable jars\n%w", err)
+		}
+	}
+
+	if ok {
 		result.Plans[0].Provides = append(result.Plans[0].Provides, libcnb.BuildPlanProvide{Name: PlanEntryJVMApplicationPackage})
+
+		boot, ok, err := SpringBootResolver{Logger: d.Logger}.Resolve(l)
+		if err != nil {
+			return libcnb.DetectResult{}, fmt.Errorf("unable to resolve Spring Boot application\n%w", err)
+		}
+
+		if ok {
+			result.Plans[0].Provides = append(result.Plans[0].Provides, libcnb.BuildPlanProvide{Name: PlanEntrySpringBootApplication})
+			result.Plans[0].Requires = append(result.Plans[0].Requires, libcnb.BuildPlanRequire{
+				Name:     PlanEntrySpringBootApplication,
+				Metadata: boot.PlanMetadata(context.Application.Path),
+			})
+		}
 	}
 
 	if cr.ResolveBool("BP_LIVE_RELOAD_ENABLED") {
@@ -82,6 +82,35 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("application root contains bin without jars", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "bin", "www"), []byte("#!/usr/bin/env node\n"), 0755)).To(Succeed())
+		})
+
+		it("does not provide jvm-application-package", func() {
+			result, err := detect.Detect(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Plans[0].Provides).NotTo(ContainElement(libcnb.BuildPlanProvide{Name: "jvm-application-package"}))
+		})
+	})
+
+	context("application root contains a directory in bin", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "bin", "com"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "lib"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "lib", "app.jar"), []byte{}, 0644)).To(Succeed())
+		})
+
+		it("does not fail", func() {
+			result, err := detect.Detect(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Plans[0].Provides).NotTo(ContainElement(libcnb.BuildPlanProvide{Name: "jvm-application-package"}))
+		})
+	})
+
 	context("multiple application scripts", func() {
 		it.Before(func() {
 			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
@@ -113,6 +142,77 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("$BP_DIST_ZIP_APPLICATION is set", func() {
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_APPLICATION", "service-a")
+
+			for _, dir := range []string{"service-a", "service-b"} {
+				Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, dir, "bin"), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(ctx.Application.Path, dir, "bin", dir), []byte{}, 0755)).To(Succeed())
+			}
+		})
+
+		it("requires and provides jvm-application-package", func() {
+			result, err := detect.Detect(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Plans[0].Provides).To(ContainElement(libcnb.BuildPlanProvide{Name: "jvm-application-package"}))
+		})
+	})
+
+	context("$BP_APPLICATION_SCRIPT is set", func() {
+		it.Before(func() {
+			t.Setenv("BP_APPLICATION_SCRIPT", "bin/*")
+		})
+
+		it("requires jvm-application-package when the configured script does not exist yet", func() {
+			result, err := detect.Detect(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Pass).To(BeTrue())
+			Expect(result.Plans[0].Provides).NotTo(ContainElement(libcnb.BuildPlanProvide{Name: "jvm-application-package"}))
+			Expect(result.Plans[0].Requires).To(ContainElement(libcnb.BuildPlanRequire{Name: "jvm-application-package"}))
+		})
+
+		it("fails when the configured script is ambiguous", func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "bin", "script-1"), []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "bin", "script-2"), []byte{}, 0755)).To(Succeed())
+
+			_, err := detect.Detect(ctx)
+			Expect(err).To(MatchError(ContainSubstring("too many application scripts in bin/*")))
+		})
+	})
+
+	context("$BP_DIST_ZIP_STRICT is true", func() {
+		it.Before(func() {
+			t.Setenv("BP_DIST_ZIP_STRICT", "true")
+
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "script-1"), []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "script-2"), []byte{}, 0755)).To(Succeed())
+		})
+
+		it("fails when the default pattern is ambiguous", func() {
+			_, err := detect.Detect(ctx)
+			Expect(err).To(MatchError(ContainSubstring("too many application scripts in */bin/*")))
+		})
+	})
+
+	context("several runnable jars", func() {
+		it.Before(func() {
+			writeJar(t, filepath.Join(ctx.Application.Path, "alpha.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Alpha\n"})
+			writeJar(t, filepath.Join(ctx.Application.Path, "bravo.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Bravo\n"})
+		})
+
+		it("fails when strict", func() {
+			t.Setenv("BP_DIST_ZIP_STRICT", "true")
+
+			_, err := detect.Detect(ctx)
+			Expect(err).To(MatchError(ContainSubstring("too many runnable jars")))
+		})
+	})
+
 	context("single application script", func() {
 		it.Before(func() {
 			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
@@ -140,6 +240,73 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("bootDistZip", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(ctx.Application.Path, "app", "bin", "app"), []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+exec "$JAVACMD" -jar "$CLASSPATH" "$@"
+`), 0755)).To(Succeed())
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), map[string]string{
+				"META-INF/MANIFEST.MF": "Main-Class: org.springframework.boot.loader.launch.JarLauncher\nStart-Class: com.example.Main\nSpring-Boot-Version: 3.2.1\n",
+			})
+		})
+
+		it("requires and provides spring-boot-application", func() {
+			Expect(detect.Detect(ctx)).To(Equal(libcnb.DetectResult{
+				Pass: true,
+				Plans: []libcnb.BuildPlan{
+					{
+						Provides: []libcnb.BuildPlanProvide{
+							{Name: "jvm-application"},
+							{Name: "jvm-application-package"},
+							{Name: "spring-boot-application"},
+						},
+						Requires: []libcnb.BuildPlanRequire{
+							{Name: "syft"},
+							{Name: "jre", Metadata: map[string]interface{}{"launch": true}},
+							{Name: "jvm-application-package"},
+							{Name: "jvm-application"},
+							{Name: "spring-boot-application", Metadata: map[string]interface{}{
+								"jar":         filepath.Join("app", "lib", "app.jar"),
+								"version":     "3.2.1",
+								"start-class": "com.example.Main",
+							}},
+						},
+					},
+				},
+			}))
+		})
+	})
+
+	context("runnable jar", func() {
+		it.Before(func() {
+			writeJar(t, filepath.Join(ctx.Application.Path, "app", "lib", "app.jar"), map[string]string{
+				"META-INF/MANIFEST.MF": "Main-Class: com.example.Main\n",
+			})
+		})
+
+		it("requires and provides jvm-application-package", func() {
+			Expect(detect.Detect(ctx)).To(Equal(libcnb.DetectResult{
+				Pass: true,
+				Plans: []libcnb.BuildPlan{
+					{
+						Provides: []libcnb.BuildPlanProvide{
+							{Name: "jvm-application"},
+							{Name: "jvm-application-package"},
+						},
+						Requires: []libcnb.BuildPlanRequire{
+							{Name: "syft"},
+							{Name: "jre", Metadata: map[string]interface{}{"launch": true}},
+							{Name: "jvm-application-package"},
+							{Name: "jvm-application"},
+						},
+					},
+				},
+			}))
+		})
+	})
+
 	context("$BP_LIVE_RELOAD_ENABLED is set", func() {
 		it.Before(func() {
 			t.Setenv("BP_LIVE_RELOAD_ENABLED", "true")
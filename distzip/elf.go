@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// TargetArch returns the architecture of the image being built, preferring $CNB_TARGET_ARCH and $BP_ARCH over the
+// architecture of the running buildpack.
+func TargetArch() string {
+	for _, name := range []string{"CNB_TARGET_ARCH", "BP_ARCH"} {
+		if arch, ok := os.LookupEnv(name); ok && arch != "" {
+			return arch
+		}
+	}
+
+	return runtime.GOARCH
+}
+
+// ELFArch returns the architecture of an ELF file using GOARCH naming, and false if the file is not an ELF file.
+func ELFArch(path string) (string, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(elf.ELFMAG))
+	if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, []byte(elf.ELFMAG)) {
+		return "", false, nil
+	}
+
+	e, err := elf.NewFile(f)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to parse ELF header of %s\n%w", path, err)
+	}
+
+	switch e.Machine {
+	case elf.EM_X86_64:
+		return "amd64", true, nil
+	case elf.EM_AARCH64:
+		return "arm64", true, nil
+	case elf.EM_386:
+		return "386", true, nil
+	case elf.EM_ARM:
+		return "arm", true, nil
+	case elf.EM_PPC64:
+		if e.Data == elf.ELFDATA2LSB {
+			return "ppc64le", true, nil
+		}
+		return "ppc64", true, nil
+	case elf.EM_S390:
+		return "s390x", true, nil
+	case elf.EM_RISCV:
+		return "riscv64", true, nil
+	default:
+		return strings.ToLower(strings.TrimPrefix(e.Machine.String(), "EM_")), true, nil
+	}
+}
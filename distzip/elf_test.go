@@ -0,0 +1,120 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testELF(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	context("TargetArch", func() {
+		it("prefers $CNB_TARGET_ARCH", func() {
+			t.Setenv("CNB_TARGET_ARCH", "arm64")
+			t.Setenv("BP_ARCH", "amd64")
+
+			Expect(distzip.TargetArch()).To(Equal("arm64"))
+		})
+
+		it("falls back to $BP_ARCH", func() {
+			t.Setenv("CNB_TARGET_ARCH", "")
+			t.Setenv("BP_ARCH", "arm64")
+
+			Expect(distzip.TargetArch()).To(Equal("arm64"))
+		})
+
+		it("falls back to the runtime architecture", func() {
+			t.Setenv("CNB_TARGET_ARCH", "")
+			t.Setenv("BP_ARCH", "")
+
+			Expect(distzip.TargetArch()).To(Equal(runtime.GOARCH))
+		})
+	})
+
+	context("ELFArch", func() {
+		it("returns amd64 for x86-64 files", func() {
+			writeELF(t, filepath.Join(path, "alpha"), elf.EM_X86_64)
+
+			arch, ok, err := distzip.ELFArch(filepath.Join(path, "alpha"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(arch).To(Equal("amd64"))
+		})
+
+		it("returns arm64 for aarch64 files", func() {
+			writeELF(t, filepath.Join(path, "alpha"), elf.EM_AARCH64)
+
+			arch, ok, err := distzip.ELFArch(filepath.Join(path, "alpha"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(arch).To(Equal("arm64"))
+		})
+
+		it("returns false for non-ELF files", func() {
+			Expect(os.WriteFile(filepath.Join(path, "alpha"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+
+			_, ok, err := distzip.ELFArch(filepath.Join(path, "alpha"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		it("returns false for empty files", func() {
+			Expect(os.WriteFile(filepath.Join(path, "alpha"), []byte{}, 0755)).To(Succeed())
+
+			_, ok, err := distzip.ELFArch(filepath.Join(path, "alpha"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+}
+
+func writeELF(t *testing.T, path string, machine elf.Machine) {
+	t.Helper()
+	Expect := NewWithT(t).Expect
+
+	buf := &bytes.Buffer{}
+	Expect(binary.Write(buf, binary.LittleEndian, elf.Header64{
+		Ident:   [elf.EI_NIDENT]byte{0x7f, 'E', 'L', 'F', byte(elf.ELFCLASS64), byte(elf.ELFDATA2LSB), byte(elf.EV_CURRENT)},
+		Type:    uint16(elf.ET_EXEC),
+		Machine: uint16(machine),
+		Version: uint32(elf.EV_CURRENT),
+		Ehsize:  64,
+	})).To(Succeed())
+
+	Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+	Expect(os.WriteFile(path, buf.Bytes(), 0755)).To(Succeed())
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/heroku/color"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// ScriptNotFoundError indicates that an explicitly configured $BP_APPLICATION_SCRIPT matches no files.
+type ScriptNotFoundError struct {
+	Pattern string
+}
+
+func (e ScriptNotFoundError) Error() string {
+	return fmt.Sprintf("no application script matches $BP_APPLICATION_SCRIPT %s", e.Pattern)
+}
+
+// AmbiguousScriptError indicates that the application script pattern matches more than one file.
+type AmbiguousScriptError struct {
+	Pattern    string
+	Candidates []string
+
+	// Applications are the names of the distributions containing the candidates, if there is more than one.
+	Applications []string
+}
+
+func (e AmbiguousScriptError) Error() string {
+	if len(e.Applications) > 1 {
+		return fmt.Sprintf("too many application scripts in %s, candidates: %s\n"+
+			"set `$BP_DIST_ZIP_APPLICATION` to one of %s to select an application", e.Pattern, e.Candidates,
+			strings.Join(e.Applications, ", "))
+	}
+
+	return fmt.Sprintf("too many application scripts in %s, candidates: %s\n"+
+		"set a more strict `$BP_APPLICATION_SCRIPT` pattern that only matches a single script", e.Pattern, e.Candidates)
+}
+
+// ApplicationNotFoundError indicates that $BP_DIST_ZIP_APPLICATION does not name a distribution containing an
+// application script.
+type ApplicationNotFoundError struct {
+	Name         string
+	Applications []string
+}
+
+func (e ApplicationNotFoundError) Error() string {
+	return fmt.Sprintf("no application named %s, found %s", e.Name, strings.Join(e.Applications, ", "))
+}
+
+// AmbiguousJarError indicates that more than one jar in the application declares a Main-Class.
+type AmbiguousJarError struct {
+	Candidates []string
+}
+
+func (e AmbiguousJarError) Error() string {
+	return fmt.Sprintf("too many runnable jars, candidates: %s\n"+
+		"add an application script or remove the Main-Class of all but one jar", e.Candidates)
+}
+
+// ScriptOutsideApplicationError indicates that an application script resolves to a file outside of the application.
+type ScriptOutsideApplicationError struct {
+	Path   string
+	Target string
+}
+
+func (e ScriptOutsideApplicationError) Error() string {
+	return fmt.Sprintf("application script %s resolves to %s outside of the application", e.Path, e.Target)
+}
+
+// ScriptNotExecutableError indicates that the application script could not be made executable.
+type ScriptNotExecutableError struct {
+	Path string
+	Err  error
+}
+
+func (e ScriptNotExecutableError) Error() string {
+	return fmt.Sprintf("unable to make script %s executable\n%s", e.Path, e.Err)
+}
+
+func (e ScriptNotExecutableError) Unwrap() error {
+	return e.Err
+}
+
+// ArchitectureMismatchError indicates that native files in the application are not built for the target
+// architecture.
+type ArchitectureMismatchError struct {
+	Architecture string
+	Mismatches   []ArchitectureMismatch
+}
+
+func (e ArchitectureMismatchError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("native files are not built for the target architecture %s:", e.Architecture))
+	for _, m := range e.Mismatches {
+		sb.WriteString(fmt.Sprintf("\n  %s (%s)", m.Path, m.Architecture))
+	}
+	return sb.String()
+}
+
+// MemoryFlagsError indicates that the launcher hard-codes JVM options that override the memory calculator.
+type MemoryFlagsError struct {
+	Launcher string
+	Flags    []string
+}
+
+func (e MemoryFlagsError) Error() string {
+	return fmt.Sprintf("launcher %s sets %s, overriding the memory calculator\n"+
+		"remove them from the application or set `$BP_DIST_ZIP_STRIP_MEMORY_FLAGS` to remove them during the build",
+		e.Launcher, strings.Join(e.Flags, " "))
+}
+
+// LintError indicates that the classpath of the application violates lint rules.
+type LintError struct {
+	Findings []string
+}
+
+func (e LintError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("the application classpath violates lint rules:")
+	for _, f := range e.Findings {
+		sb.WriteString(fmt.Sprintf("\n  %s", f))
+	}
+	return sb.String()
+}
+
+// PolicyViolationError indicates that the application contains artifacts denied by a policy.
+type PolicyViolationError struct {
+	Policy     string
+	Violations []PolicyViolation
+}
+
+func (e PolicyViolationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("the application contains artifacts denied by policy %s:\n", e.Policy))
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ARTIFACT\tVERSION\tDENIED\tREASON\tFILE")
+	for _, v := range e.Violations {
+		versions := v.Rule.Versions
+		if versions == "" {
+			versions = "*"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", v.Artifact.Coordinates(), v.Artifact.Version, versions, v.Rule.Reason, v.Artifact.Path)
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// UnverifiedArtifactsError indicates that the versions of artifacts matching a policy rule cannot be compared with its
+// version constraint.
+type UnverifiedArtifactsError struct {
+	Policy    string
+	Artifacts []PolicyViolation
+}
+
+func (e UnverifiedArtifactsError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("unable to compare the versions of artifacts with policy %s:", e.Policy))
+	for _, a := range e.Artifacts {
+		sb.WriteString(fmt.Sprintf("\n  %s version %s is not comparable with %s (%s)",
+			a.Artifact.Coordinates(), a.Artifact.Version, a.Rule.Versions, a.Artifact.Path))
+	}
+	return sb.String()
+}
+
+// InvalidLaunchError indicates that the classpath or main class described by the launcher do not exist.
+type InvalidLaunchError struct {
+	Launcher string
+	Problems []string
+}
+
+func (e InvalidLaunchError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("launcher %s cannot start the application:", e.Launcher))
+	for _, p := range e.Problems {
+		sb.WriteString(fmt.Sprintf("\n  %s", p))
+	}
+	return sb.String()
+}
+
+// InterpreterNotFoundError indicates that the run image does not contain the interpreter of a launcher's shebang.
+type InterpreterNotFoundError struct {
+	Launcher string
+	Shebang  Shebang
+	RunImage RunImage
+
+	// DirectLaunch indicates that the process types could start java directly instead of running the launcher.
+	DirectLaunch bool
+}
+
+func (e InterpreterNotFoundError) Error() string {
+	s := fmt.Sprintf("launcher %s requires %s from %s, which run image %s does not contain",
+		e.Launcher, e.Shebang.Program(), e.Shebang, e.RunImage)
+
+	if e.DirectLaunch {
+		return s + "\nset `$BP_DIST_ZIP_DIRECT_LAUNCH` to start java directly instead of running the launcher"
+	}
+	return s + "\nuse a run image that contains it or a launcher that does not require it"
+}
+
+// warn returns err if strict is true, otherwise it logs err as a warning and returns nil.
+func warn(logger bard.Logger, strict bool, err error) error {
+	if strict {
+		return err
+	}
+
+	logger.Bodyf("%s %s", color.YellowString("WARNING:"), err)
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testErrors(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	it("formats ScriptNotFoundError", func() {
+		Expect(distzip.ScriptNotFoundError{Pattern: "bin/*"}.Error()).
+			To(Equal("no application script matches $BP_APPLICATION_SCRIPT bin/*"))
+	})
+
+	it("formats AmbiguousScriptError", func() {
+		Expect(distzip.AmbiguousScriptError{Pattern: "*/bin/*", Candidates: []string{"alpha", "bravo"}}.Error()).
+			To(Equal("too many application scripts in */bin/*, candidates: [alpha bravo]\n" +
+				"set a more strict `$BP_APPLICATION_SCRIPT` pattern that only matches a single script"))
+	})
+
+	it("formats AmbiguousScriptError for several applications", func() {
+		Expect(distzip.AmbiguousScriptError{
+			Pattern:      "*/bin/*",
+			Candidates:   []string{"service-a/bin/service-a", "service-b/bin/service-b"},
+			Applications: []string{"service-a", "service-b"},
+		}.Error()).To(Equal("too many application scripts in */bin/*, candidates: [service-a/bin/service-a service-b/bin/service-b]\n" +
+			"set `$BP_DIST_ZIP_APPLICATION` to one of service-a, service-b to select an application"))
+	})
+
+	it("formats ApplicationNotFoundError", func() {
+		Expect(distzip.ApplicationNotFoundError{Name: "service-c", Applications: []string{"service-a", "service-b"}}.Error()).
+			To(Equal("no application named service-c, found service-a, service-b"))
+	})
+
+	it("formats AmbiguousJarError", func() {
+		Expect(distzip.AmbiguousJarError{Candidates: []string{"alpha.jar", "bravo.jar"}}.Error()).
+			To(Equal("too many runnable jars, candidates: [alpha.jar bravo.jar]\n" +
+				"add an application script or remove the Main-Class of all but one jar"))
+	})
+
+	it("formats ScriptOutsideApplicationError", func() {
+		Expect(distzip.ScriptOutsideApplicationError{Path: "/workspace/bin/alpha", Target: "/etc/alpha"}.Error()).
+			To(Equal("application script /workspace/bin/alpha resolves to /etc/alpha outside of the application"))
+	})
+
+	it("formats and unwraps ScriptNotExecutableError", func() {
+		err := distzip.ScriptNotExecutableError{Path: "bin/alpha", Err: fs.ErrPermission}
+
+		Expect(err.Error()).To(Equal("unable to make script bin/alpha executable\npermission denied"))
+		Expect(errors.Is(err, fs.ErrPermission)).To(BeTrue())
+	})
+
+	it("formats ArchitectureMismatchError", func() {
+		Expect(distzip.ArchitectureMismatchError{
+			Architecture: "arm64",
+			Mismatches: []distzip.ArchitectureMismatch{
+				{Path: "lib/libalpha.so", Architecture: "amd64"},
+				{Path: "lib/libbravo.so", Architecture: "386"},
+			},
+		}.Error()).To(Equal("native files are not built for the target architecture arm64:\n" +
+			"  lib/libalpha.so (amd64)\n" +
+			"  lib/libbravo.so (386)"))
+	})
+
+	it("formats MemoryFlagsError", func() {
+		Expect(distzip.MemoryFlagsError{Launcher: "bin/alpha", Flags: []string{"-Xmx2g", "-Xss1m"}}.Error()).
+			To(Equal("launcher bin/alpha sets -Xmx2g -Xss1m, overriding the memory calculator\n" +
+				"remove them from the application or set `$BP_DIST_ZIP_STRIP_MEMORY_FLAGS` to remove them during the build"))
+	})
+
+	it("formats LintError", func() {
+		Expect(distzip.LintError{Findings: []string{"alpha", "bravo"}}.Error()).
+			To(Equal("the application classpath violates lint rules:\n  alpha\n  bravo"))
+	})
+
+	it("formats PolicyViolationError", func() {
+		Expect(distzip.PolicyViolationError{
+			Policy: "policy.toml",
+			Violations: []distzip.PolicyViolation{
+				{
+					Artifact: distzip.Artifact{Path: "lib/log4j-core-2.14.1.jar", GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.14.1"},
+					Rule:     distzip.DenyRule{Artifact: "org.apache.logging.log4j:log4j-core", Versions: "< 2.17.1", Reason: "CVE-2021-44228"},
+				},
+				{
+					Artifact: distzip.Artifact{Path: "lib/junit-4.13.2.jar", GroupID: "junit", ArtifactID: "junit", Version: "4.13.2"},
+					Rule:     distzip.DenyRule{Artifact: "junit:junit"},
+				},
+			},
+		}.Error()).To(Equal("the application contains artifacts denied by policy policy.toml:\n" +
+			"  ARTIFACT                             VERSION  DENIED    REASON          FILE\n" +
+			"  org.apache.logging.log4j:log4j-core  2.14.1   < 2.17.1  CVE-2021-44228  lib/log4j-core-2.14.1.jar\n" +
+			"  junit:junit                          4.13.2   *                         lib/junit-4.13.2.jar"))
+	})
+
+	it("formats UnverifiedArtifactsError", func() {
+		Expect(distzip.UnverifiedArtifactsError{
+			Policy: "policy.toml",
+			Artifacts: []distzip.PolicyViolation{
+				{
+					Artifact: distzip.Artifact{Path: "lib/log4j-core-custom.jar", GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "custom"},
+					Rule:     distzip.DenyRule{Artifact: "org.apache.logging.log4j:log4j-core", Versions: "< 2.17.1"},
+				},
+			},
+		}.Error()).To(Equal("unable to compare the versions of artifacts with policy policy.toml:\n" +
+			"  org.apache.logging.log4j:log4j-core version custom is not comparable with < 2.17.1 (lib/log4j-core-custom.jar)"))
+	})
+
+	it("formats InvalidLaunchError", func() {
+		Expect(distzip.InvalidLaunchError{Launcher: "bin/alpha", Problems: []string{"alpha", "bravo"}}.Error()).
+			To(Equal("launcher bin/alpha cannot start the application:\n  alpha\n  bravo"))
+	})
+
+	it("formats InterpreterNotFoundError", func() {
+		e := distzip.InterpreterNotFoundError{
+			Launcher: "bin/alpha",
+			Shebang:  distzip.Shebang{Interpreter: "/bin/sh"},
+			RunImage: distzip.RunImage{StackID: "io.buildpacks.stacks.jammy.tiny", Distro: "ubuntu", DistroVersion: "22.04"},
+		}
+
+		Expect(e.Error()).To(Equal("launcher bin/alpha requires sh from #!/bin/sh, which run image io.buildpacks.stacks.jammy.tiny (ubuntu 22.04) does not contain\n" +
+			"use a run image that contains it or a launcher that does not require it"))
+
+		e.DirectLaunch = true
+		Expect(e.Error()).To(Equal("launcher bin/alpha requires sh from #!/bin/sh, which run image io.buildpacks.stacks.jammy.tiny (ubuntu 22.04) does not contain\n" +
+			"set `$BP_DIST_ZIP_DIRECT_LAUNCH` to start java directly instead of running the launcher"))
+	})
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// IsExecutable returns true if the contents of the file at path need to be executable, that is it starts with a
+// shebang or is an ELF file.
+func IsExecutable(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer f.Close()
+
+	b := make([]byte, len(elf.ELFMAG))
+	n, err := io.ReadFull(f, b)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+	b = b[:n]
+
+	return bytes.HasPrefix(b, []byte("#!")) || bytes.Equal(b, []byte(elf.ELFMAG)), nil
+}
+
+type ExecutableRestorer struct {
+	Path string
+}
+
+// Restore adds execute permissions, for everyone with read permissions, to all files below Path that need to be
+// executable but are not, returning the paths of the files it changed.
+func (e ExecutableRestorer) Restore() ([]string, error) {
+	var restored []string
+
+	err := filepath.WalkDir(e.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("unable to stat %s\n%w", path, err)
+		}
+		if info.Mode()&0111 != 0 {
+			return nil
+		}
+
+		ok, err := IsExecutable(path)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		if err := os.Chmod(path, info.Mode()|(info.Mode()&0444)>>2); err != nil {
+			return fmt.Errorf("unable to chmod %s\n%w", path, err)
+		}
+		restored = append(restored, path)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore execute permissions in %s\n%w", e.Path, err)
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testExecutable(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	it("returns true for scripts", func() {
+		Expect(os.WriteFile(filepath.Join(path, "alpha"), []byte("#!/bin/sh\n"), 0644)).To(Succeed())
+
+		Expect(distzip.IsExecutable(filepath.Join(path, "alpha"))).To(BeTrue())
+	})
+
+	it("returns true for ELF files", func() {
+		writeELF(t, filepath.Join(path, "alpha"), elf.EM_X86_64)
+
+		Expect(distzip.IsExecutable(filepath.Join(path, "alpha"))).To(BeTrue())
+	})
+
+	it("returns false for other files", func() {
+		Expect(os.WriteFile(filepath.Join(path, "alpha"), []byte("PK\x03\x04"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(path, "bravo"), []byte("#"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(path, "charlie"), []byte{}, 0755)).To(Succeed())
+
+		Expect(distzip.IsExecutable(filepath.Join(path, "alpha"))).To(BeFalse())
+		Expect(distzip.IsExecutable(filepath.Join(path, "bravo"))).To(BeFalse())
+		Expect(distzip.IsExecutable(filepath.Join(path, "charlie"))).To(BeFalse())
+	})
+
+	context("ExecutableRestorer", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(path, "bin"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(path, "libexec"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(path, "bin", "alpha"), []byte("#!/bin/sh\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(path, "bin", "bravo"), []byte("#!/bin/sh\n"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(path, "bin", "bravo.bat"), []byte("@echo off\r\n"), 0644)).To(Succeed())
+			writeELF(t, filepath.Join(path, "libexec", "helper"), elf.EM_X86_64)
+			Expect(os.Chmod(filepath.Join(path, "libexec", "helper"), 0640)).To(Succeed())
+		})
+
+		it("restores execute permissions", func() {
+			restored, err := distzip.ExecutableRestorer{Path: path}.Restore()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(restored).To(Equal([]string{
+				filepath.Join(path, "bin", "alpha"),
+				filepath.Join(path, "libexec", "helper"),
+			}))
+
+			for p, mode := range map[string]string{
+				"bin/alpha":      "-rwxr-xr-x",
+				"bin/bravo":      "-rwxr-x---",
+				"bin/bravo.bat":  "-rw-r--r--",
+				"libexec/helper": "-rwxr-x---",
+			} {
+				info, err := os.Stat(filepath.Join(path, p))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal(mode), p)
+			}
+		})
+	})
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+type Hardener struct {
+	ApplicationPath string
+	Logger          bard.Logger
+
+	// Executables are files that stay executable even if their contents do not require it.
+	Executables []string
+}
+
+// Harden removes group and world write permissions and setuid and setgid bits from all files and directories in the
+// application, and execute permissions from files that are neither launchers nor native libraries.  Symlinks are left
+// untouched.
+func (h Hardener) Harden() error {
+	executables := map[string]bool{}
+	for _, e := range h.Executables {
+		executables[e] = true
+	}
+
+	var write, special, execute int
+
+	err := filepath.WalkDir(h.ApplicationPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == h.ApplicationPath || !(d.IsDir() || d.Type().IsRegular()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("unable to stat %s\n%w", path, err)
+		}
+
+		mode := info.Mode()
+		if mode&0022 != 0 {
+			mode &^= 0022
+			write++
+			h.Logger.Debugf("removing group and world write permissions from %s", path)
+		}
+		if mode&(os.ModeSetuid|os.ModeSetgid) != 0 {
+			mode &^= os.ModeSetuid | os.ModeSetgid
+			special++
+			h.Logger.Debugf("removing setuid and setgid from %s", path)
+		}
+		if d.Type().IsRegular() && mode&0111 != 0 && !executables[path] {
+			ok, err := IsExecutable(path)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				mode &^= 0111
+				execute++
+				h.Logger.Debugf("removing execute permissions from %s", path)
+			}
+		}
+
+		if mode != info.Mode() {
+			if err := os.Chmod(path, mode); err != nil {
+				return fmt.Errorf("unable to chmod %s\n%w", path, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to harden %s\n%w", h.ApplicationPath, err)
+	}
+
+	h.Logger.Body("Hardened application permissions")
+	h.Logger.Bodyf("  Removed group and world write permissions from %d files", write)
+	h.Logger.Bodyf("  Removed setuid and setgid from %d files", special)
+	h.Logger.Bodyf("  Removed execute permissions from %d files", execute)
+
+	return nil
+}
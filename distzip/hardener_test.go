@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"bytes"
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/paketo-buildpacks/libpak/bard"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testHardener(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		buf *bytes.Buffer
+		h   distzip.Hardener
+	)
+
+	it.Before(func() {
+		buf = &bytes.Buffer{}
+		h.ApplicationPath = t.TempDir()
+		h.Logger = bard.NewLogger(buf)
+
+		Expect(os.MkdirAll(filepath.Join(h.ApplicationPath, "app", "bin"), 0777)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(h.ApplicationPath, "app", "lib"), 0755)).To(Succeed())
+		Expect(os.Chmod(filepath.Join(h.ApplicationPath, "app", "bin"), 0777)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(h.ApplicationPath, "app", "bin", "app"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+		Expect(os.Chmod(filepath.Join(h.ApplicationPath, "app", "bin", "app"), 0777|os.ModeSetuid)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(h.ApplicationPath, "app", "bin", "launcher"), []byte{}, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(h.ApplicationPath, "app", "lib", "app.jar"), []byte{}, 0775)).To(Succeed())
+		Expect(os.Chmod(filepath.Join(h.ApplicationPath, "app", "lib", "app.jar"), 0775|os.ModeSetgid)).To(Succeed())
+		writeELF(t, filepath.Join(h.ApplicationPath, "app", "lib", "libalpha.so"), elf.EM_X86_64)
+		Expect(os.WriteFile(filepath.Join(h.ApplicationPath, "app", "lib", "app.properties"), []byte{}, 0644)).To(Succeed())
+
+		h.Executables = []string{filepath.Join(h.ApplicationPath, "app", "bin", "launcher")}
+	})
+
+	it("hardens permissions", func() {
+		Expect(h.Harden()).To(Succeed())
+
+		for path, mode := range map[string]string{
+			"app/bin":                "drwxr-xr-x",
+			"app/bin/app":            "-rwxr-xr-x",
+			"app/bin/launcher":       "-rwxr-xr-x",
+			"app/lib/app.jar":        "-rw-r--r--",
+			"app/lib/libalpha.so":    "-rwxr-xr-x",
+			"app/lib/app.properties": "-rw-r--r--",
+		} {
+			info, err := os.Stat(filepath.Join(h.ApplicationPath, path))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().String()).To(Equal(mode), path)
+		}
+
+		Expect(buf.String()).To(ContainSubstring("Removed group and world write permissions from 3 files"))
+		Expect(buf.String()).To(ContainSubstring("Removed setuid and setgid from 2 files"))
+		Expect(buf.String()).To(ContainSubstring("Removed execute permissions from 1 files"))
+	})
+}
@@ -25,8 +25,29 @@ import (
 
 func TestUnit(t *testing.T) {
 	suite := spec.New("distzip", spec.Report(report.Terminal{}))
+	suite("Architecture", testArchitecture)
+	suite("Artifact", testArtifact)
 	suite("Build", testBuild)
+	suite("Classpath", testClasspath)
 	suite("Detect", testDetect)
+	suite("ELF", testELF)
+	suite("Errors", testErrors)
+	suite("Executable", testExecutable)
+	suite("Hardener", testHardener)
+	suite("Interpreter", testInterpreter)
+	suite("Jar", testJar)
+	suite("JavaAgents", testJavaAgents)
+	suite("Launch", testLaunch)
+	suite("LaunchValidator", testLaunchValidator)
+	suite("Licenses", testLicenses)
+	suite("Lint", testLint)
+	suite("MemoryFlags", testMemoryFlags)
+	suite("NativeLibraries", testNativeLibraries)
+	suite("Policy", testPolicy)
+	suite("ProcessOptions", testProcessOptions)
+	suite("Procfile", testProcfile)
+	suite("Reproducible", testReproducible)
 	suite("ScriptResolver", testScriptResolver)
+	suite("SpringBoot", testSpringBoot)
 	suite.Run(t)
 }
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak"
+)
+
+// Shebang is the interpreter line of a script, such as #!/bin/sh or #!/usr/bin/env bash.
+type Shebang struct {
+	Interpreter string
+	Arguments   []string
+}
+
+// ReadShebang returns the shebang of the file at path, and false if the file does not start with one.
+func ReadShebang(path string) (Shebang, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Shebang{}, false, fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(io.LimitReader(f, 256)).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Shebang{}, false, fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+	if !strings.HasPrefix(line, "#!") {
+		return Shebang{}, false, nil
+	}
+
+	words := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(words) == 0 {
+		return Shebang{}, false, nil
+	}
+
+	return Shebang{Interpreter: words[0], Arguments: words[1:]}, true, nil
+}
+
+// Program returns the name of the program run by the shebang, looking through /usr/bin/env to the program it runs.
+func (s Shebang) Program() string {
+	if filepath.Base(s.Interpreter) == "env" {
+		for _, a := range s.Arguments {
+			if !strings.HasPrefix(a, "-") && !strings.Contains(a, "=") {
+				return a
+			}
+		}
+	}
+
+	return filepath.Base(s.Interpreter)
+}
+
+func (s Shebang) String() string {
+	return strings.Join(append([]string{"#!" + s.Interpreter}, s.Arguments...), " ")
+}
+
+// RunImage describes the image the application is launched on, as far as it is known at build time.
+type RunImage struct {
+	StackID       string
+	Distro        string
+	DistroVersion string
+}
+
+// NewRunImage returns the run image of stackID, with the distribution described by $CNB_TARGET_DISTRO_NAME and
+// $CNB_TARGET_DISTRO_VERSION.
+func NewRunImage(stackID string) RunImage {
+	return RunImage{
+		StackID:       stackID,
+		Distro:        os.Getenv("CNB_TARGET_DISTRO_NAME"),
+		DistroVersion: os.Getenv("CNB_TARGET_DISTRO_VERSION"),
+	}
+}
+
+// Provides returns whether the run image contains program, and false as its second value if that is not known.  Tiny
+// and static stacks contain no shells or other interpreters, full stacks contain sh and bash, and Alpine contains sh
+// but not bash.
+func (r RunImage) Provides(program string) (bool, bool) {
+	shell := program == "sh" || program == "bash" || program == "dash"
+
+	switch {
+	case libpak.IsTinyStack(r.StackID) || libpak.IsStaticStack(r.StackID):
+		return false, true
+	case libpak.IsShellPresentOnStack(r.StackID) && shell:
+		return true, true
+	case strings.EqualFold(r.Distro, "alpine") && shell:
+		return program == "sh", true
+	default:
+		return false, false
+	}
+}
+
+func (r RunImage) String() string {
+	var parts []string
+	if r.StackID != "" {
+		parts = append(parts, r.StackID)
+	}
+	if r.Distro != "" {
+		parts = append(parts, strings.TrimSpace(fmt.Sprintf("%s %s", r.Distro, r.DistroVersion)))
+	}
+
+	switch len(parts) {
+	case 0:
+		return "unknown"
+	case 1:
+		return parts[0]
+	default:
+		return fmt.Sprintf("%s (%s)", parts[0], parts[1])
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testInterpreter(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	context("ReadShebang", func() {
+		var path string
+
+		it.Before(func() {
+			path = filepath.Join(t.TempDir(), "app")
+		})
+
+		it("returns interpreter and arguments", func() {
+			Expect(os.WriteFile(path, []byte("#! /usr/bin/env -S bash -e\nexec java\n"), 0755)).To(Succeed())
+
+			s, ok, err := distzip.ReadShebang(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(distzip.Shebang{Interpreter: "/usr/bin/env", Arguments: []string{"-S", "bash", "-e"}}))
+			Expect(s.String()).To(Equal("#!/usr/bin/env -S bash -e"))
+		})
+
+		it("returns false without a shebang", func() {
+			Expect(os.WriteFile(path, []byte("\x7fELF"), 0755)).To(Succeed())
+
+			_, ok, err := distzip.ReadShebang(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("returns false for an empty file", func() {
+			Expect(os.WriteFile(path, []byte{}, 0755)).To(Succeed())
+
+			_, ok, err := distzip.ReadShebang(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	context("Shebang", func() {
+		it("returns the interpreter program", func() {
+			Expect(distzip.Shebang{Interpreter: "/bin/sh"}.Program()).To(Equal("sh"))
+		})
+
+		it("returns the program run by env", func() {
+			Expect(distzip.Shebang{Interpreter: "/usr/bin/env", Arguments: []string{"-S", "LANG=C", "bash", "-e"}}.Program()).
+				To(Equal("bash"))
+		})
+	})
+
+	context("RunImage", func() {
+		it("does not provide interpreters on tiny and static stacks", func() {
+			for _, stack := range []string{"io.buildpacks.stacks.jammy.tiny", "io.buildpacks.stacks.noble.static"} {
+				found, known := distzip.RunImage{StackID: stack}.Provides("sh")
+				Expect(known).To(BeTrue())
+				Expect(found).To(BeFalse())
+			}
+		})
+
+		it("provides shells on full stacks", func() {
+			found, known := distzip.RunImage{StackID: "io.buildpacks.stacks.jammy"}.Provides("bash")
+			Expect(known).To(BeTrue())
+			Expect(found).To(BeTrue())
+
+			_, known = distzip.RunImage{StackID: "io.buildpacks.stacks.jammy"}.Provides("python3")
+			Expect(known).To(BeFalse())
+		})
+
+		it("provides sh but not bash on alpine", func() {
+			found, known := distzip.RunImage{Distro: "alpine"}.Provides("sh")
+			Expect(known).To(BeTrue())
+			Expect(found).To(BeTrue())
+
+			found, known = distzip.RunImage{Distro: "alpine"}.Provides("bash")
+			Expect(known).To(BeTrue())
+			Expect(found).To(BeFalse())
+		})
+
+		it("does not know about other run images", func() {
+			_, known := distzip.RunImage{StackID: "com.example.stack", Distro: "debian"}.Provides("sh")
+			Expect(known).To(BeFalse())
+		})
+
+		it("reads the target distribution", func() {
+			t.Setenv("CNB_TARGET_DISTRO_NAME", "ubuntu")
+			t.Setenv("CNB_TARGET_DISTRO_VERSION", "24.04")
+
+			r := distzip.NewRunImage("io.buildpacks.stacks.noble.tiny")
+			Expect(r).To(Equal(distzip.RunImage{StackID: "io.buildpacks.stacks.noble.tiny", Distro: "ubuntu", DistroVersion: "24.04"}))
+			Expect(r.String()).To(Equal("io.buildpacks.stacks.noble.tiny (ubuntu 24.04)"))
+			Expect(distzip.RunImage{}.String()).To(Equal("unknown"))
+		})
+	})
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// ReadManifest returns the main attributes of the META-INF/MANIFEST.MF of a jar, and an empty map if it has none.
+func ReadManifest(path string) (map[string]string, error) {
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer z.Close()
+
+	for _, f := range z.File {
+		if f.Name != "META-INF/MANIFEST.MF" {
+			continue
+		}
+
+		in, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to open manifest in %s\n%w", path, err)
+		}
+		defer in.Close()
+
+		m, err := parseManifest(in)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse manifest in %s\n%w", path, err)
+		}
+		return m, nil
+	}
+
+	return map[string]string{}, nil
+}
+
+// parseManifest parses the main section of a manifest, joining continuation lines.
+func parseManifest(in io.Reader) (map[string]string, error) {
+	var (
+		m    = map[string]string{}
+		last string
+	)
+
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if line == "" {
+			break
+		}
+
+		if strings.HasPrefix(line, " ") && last != "" {
+			m[last] += line[1:]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		last = strings.TrimSpace(key)
+		m[last] = strings.TrimSpace(value)
+	}
+
+	return m, s.Err()
+}
+
+// JarPatterns are the locations searched for a runnable jar when the application has no start script.
+var JarPatterns = []string{
+	"*.jar",
+	"lib/*.jar",
+	"*/*.jar",
+	"*/lib/*.jar",
+}
+
+type JarResolver struct {
+	ApplicationPath string
+	Logger          bard.Logger
+
+	// Strict indicates that more than one jar declaring a Main-Class is an error.
+	Strict bool
+}
+
+// Resolve describes how to start a directory of jars in which exactly one jar declares a Main-Class, returning false
+// if there is no such jar or more than one, unless Strict is set.  Exploded jars, which have a META-INF/MANIFEST.MF in the application
+// root, are left to other buildpacks.
+func (j JarResolver) Resolve() (Launch, bool, error) {
+	if _, err := os.Stat(filepath.Join(j.ApplicationPath, "META-INF", "MANIFEST.MF")); err == nil {
+		j.Logger.Debug("application is an exploded jar")
+		return Launch{}, false, nil
+	}
+
+	var (
+		candidates []string
+		manifests  = map[string]map[string]string{}
+	)
+
+	for _, pattern := range JarPatterns {
+		files, err := filepath.Glob(filepath.Join(j.ApplicationPath, pattern))
+		if err != nil {
+			return Launch{}, false, fmt.Errorf("unable to find files with %s\n%w", pattern, err)
+		}
+
+		for _, f := range files {
+			m, err := ReadManifest(f)
+			if err != nil {
+				j.Logger.Debugf("ignoring %s: %s", f, err)
+				continue
+			}
+
+			if m["Main-Class"] != "" {
+				candidates = append(candidates, f)
+				manifests[f] = m
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return Launch{}, false, nil
+	case 1:
+	default:
+		sort.Strings(candidates)
+		if j.Strict {
+			return Launch{}, false, AmbiguousJarError{Candidates: candidates}
+		}
+		j.Logger.Debugf("too many runnable jars, candidates: %s", candidates)
+		return Launch{}, false, nil
+	}
+
+	jar, m := candidates[0], manifests[candidates[0]]
+
+	home := filepath.Dir(jar)
+	if filepath.Base(home) == "lib" && home != j.ApplicationPath {
+		home = filepath.Dir(home)
+	}
+
+	launch := Launch{
+		Kind:      LaunchKindJar,
+		Launcher:  jar,
+		Home:      home,
+		ClassPath: []string{jar},
+		MainClass: m["Main-Class"],
+		MainJar:   jar,
+	}
+
+	for _, e := range strings.Fields(m["Class-Path"]) {
+		if strings.Contains(e, ":") {
+			continue
+		}
+		launch.ClassPath = append(launch.ClassPath, filepath.Join(filepath.Dir(jar), filepath.FromSlash(e)))
+	}
+
+	return launch, true, nil
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testJar(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	context("ReadManifest", func() {
+		it("returns main attributes", func() {
+			writeJar(t, filepath.Join(path, "alpha.jar"), map[string]string{
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\r\nMain-Class: com.example.Main\r\nClass-Path: alpha-1.0.jar bra\r\n vo-2.0.jar\r\n\r\nName: com/example/\r\nSealed: true\r\n",
+			})
+
+			Expect(distzip.ReadManifest(filepath.Join(path, "alpha.jar"))).To(Equal(map[string]string{
+				"Manifest-Version": "1.0",
+				"Main-Class":       "com.example.Main",
+				"Class-Path":       "alpha-1.0.jar bravo-2.0.jar",
+			}))
+		})
+
+		it("returns an empty map without a manifest", func() {
+			writeJar(t, filepath.Join(path, "alpha.jar"), map[string]string{"com/example/Main.class": ""})
+
+			Expect(distzip.ReadManifest(filepath.Join(path, "alpha.jar"))).To(BeEmpty())
+		})
+	})
+
+	context("JarResolver", func() {
+		var r distzip.JarResolver
+
+		it.Before(func() {
+			r.ApplicationPath = path
+
+			writeJar(t, filepath.Join(path, "app", "lib", "guava-33.0.jar"), map[string]string{
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+			})
+		})
+
+		it("returns false without a runnable jar", func() {
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("describes a runnable jar", func() {
+			writeJar(t, filepath.Join(path, "app", "lib", "app.jar"), map[string]string{
+				"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\nMain-Class: com.example.Main\nClass-Path: guava-33.0.jar\n",
+			})
+
+			l, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(l).To(Equal(distzip.Launch{
+				Kind:     distzip.LaunchKindJar,
+				Launcher: filepath.Join(path, "app", "lib", "app.jar"),
+				Home:     filepath.Join(path, "app"),
+				ClassPath: []string{
+					filepath.Join(path, "app", "lib", "app.jar"),
+					filepath.Join(path, "app", "lib", "guava-33.0.jar"),
+				},
+				MainClass: "com.example.Main",
+				MainJar:   filepath.Join(path, "app", "lib", "app.jar"),
+			}))
+			Expect(l.JavaArguments()).To(Equal([]string{"-jar", filepath.Join(path, "app", "lib", "app.jar")}))
+		})
+
+		it("returns false for more than one runnable jar", func() {
+			writeJar(t, filepath.Join(path, "alpha.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Alpha\n"})
+			writeJar(t, filepath.Join(path, "bravo.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Bravo\n"})
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("fails for more than one runnable jar when strict", func() {
+			r.Strict = true
+			writeJar(t, filepath.Join(path, "alpha.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Alpha\n"})
+			writeJar(t, filepath.Join(path, "bravo.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Bravo\n"})
+
+			_, _, err := r.Resolve()
+			Expect(err).To(MatchError(distzip.AmbiguousJarError{Candidates: []string{
+				filepath.Join(path, "alpha.jar"),
+				filepath.Join(path, "bravo.jar"),
+			}}))
+		})
+
+		it("returns false for exploded jars", func() {
+			writeJar(t, filepath.Join(path, "alpha.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Alpha\n"})
+			Expect(os.MkdirAll(filepath.Join(path, "META-INF"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(path, "META-INF", "MANIFEST.MF"), []byte("Main-Class: com.example.Main\n"), 0644)).To(Succeed())
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+	})
+}
+
+func writeJar(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+	Expect := NewWithT(t).Expect
+
+	Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+
+	f, err := os.Create(path)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+
+	z := zip.NewWriter(f)
+	for name, content := range entries {
+		w, err := z.Create(name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = w.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(z.Close()).To(Succeed())
+}
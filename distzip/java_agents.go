@@ -0,0 +1,57 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// JavaAgents is an exec.d helper that adds the agents in $BPL_DIST_ZIP_JAVA_AGENTS, a whitespace separated list of
+// agent jars with optional options such as /bindings/agent/agent.jar=port=8080, to $JAVA_TOOL_OPTIONS at launch.
+type JavaAgents struct {
+	Logger bard.Logger
+}
+
+func (j JavaAgents) Execute() (map[string]string, error) {
+	agents := strings.Fields(os.Getenv("BPL_DIST_ZIP_JAVA_AGENTS"))
+	if len(agents) == 0 {
+		return nil, nil
+	}
+
+	var values []string
+	if s, ok := os.LookupEnv("JAVA_TOOL_OPTIONS"); ok && s != "" {
+		values = append(values, s)
+	}
+
+	for _, a := range agents {
+		path, _, _ := strings.Cut(a, "=")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, fmt.Errorf("java agent %s does not exist", path)
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to stat %s\n%w", path, err)
+		}
+
+		j.Logger.Infof("Adding Java agent %s", path)
+		values = append(values, fmt.Sprintf("-javaagent:%s", a))
+	}
+
+	return map[string]string{"JAVA_TOOL_OPTIONS": strings.Join(values, " ")}, nil
+}
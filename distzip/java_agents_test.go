@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testJavaAgents(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+		j    distzip.JavaAgents
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+		Expect(os.WriteFile(filepath.Join(path, "alpha.jar"), []byte{}, 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(path, "bravo.jar"), []byte{}, 0644)).To(Succeed())
+	})
+
+	it("does nothing without $BPL_DIST_ZIP_JAVA_AGENTS", func() {
+		Expect(j.Execute()).To(BeNil())
+	})
+
+	context("$BPL_DIST_ZIP_JAVA_AGENTS is set", func() {
+		it.Before(func() {
+			t.Setenv("BPL_DIST_ZIP_JAVA_AGENTS", filepath.Join(path, "alpha.jar")+" "+filepath.Join(path, "bravo.jar")+"=port=8080:config.yaml")
+		})
+
+		it("adds agents to $JAVA_TOOL_OPTIONS", func() {
+			Expect(j.Execute()).To(Equal(map[string]string{
+				"JAVA_TOOL_OPTIONS": "-javaagent:" + filepath.Join(path, "alpha.jar") + " -javaagent:" + filepath.Join(path, "bravo.jar") + "=port=8080:config.yaml",
+			}))
+		})
+
+		it("appends agents to existing $JAVA_TOOL_OPTIONS", func() {
+			t.Setenv("JAVA_TOOL_OPTIONS", "-Dalpha=bravo")
+
+			Expect(j.Execute()).To(Equal(map[string]string{
+				"JAVA_TOOL_OPTIONS": "-Dalpha=bravo -javaagent:" + filepath.Join(path, "alpha.jar") + " -javaagent:" + filepath.Join(path, "bravo.jar") + "=port=8080:config.yaml",
+			}))
+		})
+
+		it("fails if an agent does not exist", func() {
+			Expect(os.Remove(filepath.Join(path, "bravo.jar"))).To(Succeed())
+
+			_, err := j.Execute()
+			Expect(err).To(MatchError("java agent " + filepath.Join(path, "bravo.jar") + " does not exist"))
+		})
+	})
+}
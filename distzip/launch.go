@@ -0,0 +1,275 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+type LaunchKind string
+
+const (
+	LaunchKindScript   LaunchKind = "script"
+	LaunchKindJPackage LaunchKind = "jpackage"
+	LaunchKindNative   LaunchKind = "native"
+	LaunchKindJar      LaunchKind = "jar"
+)
+
+// Launch is a structured description of how a distribution starts its JVM.
+type Launch struct {
+	Kind LaunchKind
+
+	// Launcher is the script, native executable or runnable jar that starts the application.
+	Launcher string
+
+	// Home is the root of the distribution, the directory the launcher refers to as APP_HOME.
+	Home string
+
+	ClassPath  []string
+	MainClass  string
+	MainJar    string
+	JVMOptions []string
+
+	// OptsVariable is the application specific environment variable the launcher reads JVM options from.
+	OptsVariable string
+}
+
+// Direct returns true if the JVM can be started directly, without going through the launcher.
+func (l Launch) Direct() bool {
+	return l.MainJar != "" || (l.MainClass != "" && len(l.ClassPath) > 0)
+}
+
+// JavaArguments returns the arguments to pass to java when starting the JVM directly.
+func (l Launch) JavaArguments() []string {
+	args := append([]string{}, l.JVMOptions...)
+
+	if l.MainJar != "" {
+		return append(args, "-jar", l.MainJar)
+	}
+
+	return append(args, "-cp", strings.Join(l.ClassPath, string(filepath.ListSeparator)), l.MainClass)
+}
+
+// OptionsVariable returns the environment variable to pass additional JVM options through, the launcher specific
+// variable if there is one, $JAVA_OPTS for other scripts and $JAVA_TOOL_OPTIONS for all other launchers.
+func (l Launch) OptionsVariable() string {
+	switch {
+	case l.OptsVariable != "":
+		return l.OptsVariable
+	case l.Kind == LaunchKindScript:
+		return "JAVA_OPTS"
+	default:
+		return "JAVA_TOOL_OPTIONS"
+	}
+}
+
+type LaunchResolver struct {
+	Logger bard.Logger
+}
+
+var (
+	scriptClassPath  = regexp.MustCompile(`(?m)^CLASSPATH=(.*)$`)
+	scriptJVMOptions = regexp.MustCompile(`(?m)^DEFAULT_JVM_OPTS=(.*)$`)
+	scriptMainClass  = regexp.MustCompile(`-(?:classpath|cp)\s+"(?:[^"\\]|\\.)*"\s*(?:\\\s*)?([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)`)
+	scriptMainJar    = regexp.MustCompile(`-jar\s+"(?:[^"\\]|\\.)*\$CLASSPATH(?:[^"\\]|\\.)*"`)
+	scriptOpts       = regexp.MustCompile(`\$\{?([A-Z][A-Z0-9_]*_OPTS)\b`)
+)
+
+// Resolve describes how the launcher starts the application.  Shell scripts are parsed for Gradle-style CLASSPATH,
+// DEFAULT_JVM_OPTS and main class declarations and jpackage app-image launchers are described by their .cfg file.
+func (l LaunchResolver) Resolve(launcher string) (Launch, error) {
+	launch := Launch{
+		Kind:     LaunchKindScript,
+		Launcher: launcher,
+		Home:     filepath.Dir(filepath.Dir(launcher)),
+	}
+
+	_, ok, err := ELFArch(launcher)
+	if err != nil {
+		return Launch{}, fmt.Errorf("unable to read launcher %s\n%w", launcher, err)
+	}
+
+	if ok {
+		launch.Kind = LaunchKindNative
+
+		cfg := filepath.Join(launch.Home, "lib", "app", fmt.Sprintf("%s.cfg", filepath.Base(launcher)))
+		if _, err := os.Stat(cfg); os.IsNotExist(err) {
+			l.Logger.Debugf("native launcher %s has no jpackage configuration at %s", launcher, cfg)
+			return launch, nil
+		} else if err != nil {
+			return Launch{}, fmt.Errorf("unable to stat %s\n%w", cfg, err)
+		}
+
+		launch.Kind = LaunchKindJPackage
+		if err := l.parseJPackageConfiguration(cfg, &launch); err != nil {
+			return Launch{}, fmt.Errorf("unable to parse jpackage configuration %s\n%w", cfg, err)
+		}
+
+		return launch, nil
+	}
+
+	b, err := os.ReadFile(launcher)
+	if err != nil {
+		return Launch{}, fmt.Errorf("unable to read launcher %s\n%w", launcher, err)
+	}
+	l.parseScript(string(b), &launch)
+
+	return launch, nil
+}
+
+func (LaunchResolver) parseScript(script string, launch *Launch) {
+	expand := func(s string) string {
+		s = strings.ReplaceAll(s, "${APP_HOME}", launch.Home)
+		return strings.ReplaceAll(s, "$APP_HOME", launch.Home)
+	}
+
+	if m := scriptClassPath.FindStringSubmatch(script); m != nil {
+		for _, w := range splitWords(m[1]) {
+			for _, e := range strings.Split(w, ":") {
+				if e != "" {
+					launch.ClassPath = append(launch.ClassPath, filepath.Clean(expand(e)))
+				}
+			}
+		}
+	}
+
+	if m := scriptJVMOptions.FindStringSubmatch(script); m != nil {
+		for _, w := range splitWords(m[1]) {
+			for _, o := range splitWords(w) {
+				launch.JVMOptions = append(launch.JVMOptions, expand(o))
+			}
+		}
+	}
+
+	if scriptMainJar.MatchString(script) && len(launch.ClassPath) == 1 {
+		launch.MainJar = launch.ClassPath[0]
+	} else if m := scriptMainClass.FindStringSubmatch(script); m != nil {
+		launch.MainClass = m[1]
+	}
+
+	for _, m := range scriptOpts.FindAllStringSubmatch(script, -1) {
+		if m[1] != "DEFAULT_JVM_OPTS" && m[1] != "JAVA_OPTS" {
+			launch.OptsVariable = m[1]
+			break
+		}
+	}
+}
+
+func (LaunchResolver) parseJPackageConfiguration(path string, launch *Launch) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer f.Close()
+
+	app := filepath.Dir(path)
+	expand := strings.NewReplacer(
+		"$APPDIR", app,
+		"$ROOTDIR", launch.Home,
+		"$BINDIR", filepath.Dir(launch.Launcher),
+	)
+
+	section := ""
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = expand.Replace(value)
+
+		switch {
+		case section == "Application" && key == "app.classpath":
+			for _, e := range strings.Split(value, string(filepath.ListSeparator)) {
+				if e != "" {
+					launch.ClassPath = append(launch.ClassPath, filepath.Clean(e))
+				}
+			}
+		case section == "Application" && key == "app.mainclass":
+			launch.MainClass = value
+		case section == "Application" && key == "app.mainjar":
+			launch.MainJar = filepath.Clean(value)
+		case section == "JavaOptions" && key == "java-options":
+			launch.JVMOptions = append(launch.JVMOptions, value)
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+
+	return nil
+}
+
+// splitWords splits s into words the way a shell would, honouring single quotes, double quotes and backslash escapes.
+func splitWords(s string) []string {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if inWord && word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	return words
+}
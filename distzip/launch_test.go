@@ -0,0 +1,179 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testLaunch(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		home string
+		r    distzip.LaunchResolver
+	)
+
+	it.Before(func() {
+		home = filepath.Join(t.TempDir(), "app")
+		Expect(os.MkdirAll(filepath.Join(home, "bin"), 0755)).To(Succeed())
+	})
+
+	context("Gradle start script", func() {
+		it("parses classpath, main class, jvm options and opts variable", func() {
+			Expect(os.WriteFile(filepath.Join(home, "bin", "app"), []byte(`#!/bin/sh
+# Add default JVM options here. You can also use JAVA_OPTS and APP_OPTS to pass JVM options to this script.
+DEFAULT_JVM_OPTS='"-Xss512k" "-Dapp.home=$APP_HOME"'
+
+CLASSPATH=$APP_HOME/lib/app.jar:$APP_HOME/lib/guava-33.0.jar
+
+set -- \
+        "-Dorg.gradle.appname=$APP_BASE_NAME" \
+        -classpath "$CLASSPATH" \
+        com.example.Main \
+        "$@"
+
+eval "set -- $( printf '%s\n' "$DEFAULT_JVM_OPTS $JAVA_OPTS $APP_OPTS" )" '"$@"'
+exec "$JAVACMD" "$@"
+`), 0755)).To(Succeed())
+
+			l, err := r.Resolve(filepath.Join(home, "bin", "app"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l).To(Equal(distzip.Launch{
+				Kind:     distzip.LaunchKindScript,
+				Launcher: filepath.Join(home, "bin", "app"),
+				Home:     home,
+				ClassPath: []string{
+					filepath.Join(home, "lib", "app.jar"),
+					filepath.Join(home, "lib", "guava-33.0.jar"),
+				},
+				MainClass:    "com.example.Main",
+				JVMOptions:   []string{"-Xss512k", "-Dapp.home=" + home},
+				OptsVariable: "APP_OPTS",
+			}))
+			Expect(l.Direct()).To(BeTrue())
+			Expect(l.JavaArguments()).To(Equal([]string{
+				"-Xss512k",
+				"-Dapp.home=" + home,
+				"-cp", filepath.Join(home, "lib", "app.jar") + ":" + filepath.Join(home, "lib", "guava-33.0.jar"),
+				"com.example.Main",
+			}))
+		})
+
+		it("parses legacy eval style scripts", func() {
+			Expect(os.WriteFile(filepath.Join(home, "bin", "app"), []byte(`#!/usr/bin/env sh
+DEFAULT_JVM_OPTS=""
+CLASSPATH=$APP_HOME/lib/app.jar
+eval set -- $DEFAULT_JVM_OPTS $JAVA_OPTS $APP_OPTS -classpath "\"$CLASSPATH\"" com.example.Main "$APP_ARGS"
+`), 0755)).To(Succeed())
+
+			l, err := r.Resolve(filepath.Join(home, "bin", "app"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l.ClassPath).To(Equal([]string{filepath.Join(home, "lib", "app.jar")}))
+			Expect(l.MainClass).To(Equal("com.example.Main"))
+			Expect(l.JVMOptions).To(BeEmpty())
+		})
+
+		it("parses -jar scripts", func() {
+			Expect(os.WriteFile(filepath.Join(home, "bin", "app"), []byte(`#!/bin/sh
+CLASSPATH=$APP_HOME/lib/app.jar
+eval set -- $DEFAULT_JVM_OPTS $JAVA_OPTS $APP_OPTS -jar "\"$CLASSPATH\"" "$APP_ARGS"
+`), 0755)).To(Succeed())
+
+			l, err := r.Resolve(filepath.Join(home, "bin", "app"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l.MainJar).To(Equal(filepath.Join(home, "lib", "app.jar")))
+			Expect(l.MainClass).To(BeEmpty())
+			Expect(l.JavaArguments()).To(Equal([]string{"-jar", filepath.Join(home, "lib", "app.jar")}))
+		})
+
+		it("describes unknown scripts", func() {
+			Expect(os.WriteFile(filepath.Join(home, "bin", "app"), []byte("#!/bin/sh\necho hello\n"), 0755)).To(Succeed())
+
+			l, err := r.Resolve(filepath.Join(home, "bin", "app"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l).To(Equal(distzip.Launch{
+				Kind:     distzip.LaunchKindScript,
+				Launcher: filepath.Join(home, "bin", "app"),
+				Home:     home,
+			}))
+			Expect(l.Direct()).To(BeFalse())
+		})
+	})
+
+	context("jpackage app-image", func() {
+		it.Before(func() {
+			writeELF(t, filepath.Join(home, "bin", "app"), elf.EM_AARCH64)
+			Expect(os.MkdirAll(filepath.Join(home, "lib", "app"), 0755)).To(Succeed())
+		})
+
+		it("parses the launcher configuration", func() {
+			Expect(os.WriteFile(filepath.Join(home, "lib", "app", "app.cfg"), []byte(`[Application]
+app.classpath=$APPDIR/app.jar
+app.classpath=$APPDIR/guava-33.0.jar
+app.mainclass=com.example.Main
+
+[JavaOptions]
+java-options=-Djpackage.app-version=1.0
+java-options=-Dapp.root=$ROOTDIR
+`), 0644)).To(Succeed())
+
+			l, err := r.Resolve(filepath.Join(home, "bin", "app"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l).To(Equal(distzip.Launch{
+				Kind:     distzip.LaunchKindJPackage,
+				Launcher: filepath.Join(home, "bin", "app"),
+				Home:     home,
+				ClassPath: []string{
+					filepath.Join(home, "lib", "app", "app.jar"),
+					filepath.Join(home, "lib", "app", "guava-33.0.jar"),
+				},
+				MainClass:  "com.example.Main",
+				JVMOptions: []string{"-Djpackage.app-version=1.0", "-Dapp.root=" + home},
+			}))
+		})
+
+		it("describes native launchers without configuration", func() {
+			Expect(os.RemoveAll(filepath.Join(home, "lib"))).To(Succeed())
+
+			l, err := r.Resolve(filepath.Join(home, "bin", "app"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l.Kind).To(Equal(distzip.LaunchKindNative))
+			Expect(l.Direct()).To(BeFalse())
+		})
+	})
+
+	it("returns the options variable", func() {
+		Expect(distzip.Launch{Kind: distzip.LaunchKindScript, OptsVariable: "APP_OPTS"}.OptionsVariable()).To(Equal("APP_OPTS"))
+		Expect(distzip.Launch{Kind: distzip.LaunchKindScript}.OptionsVariable()).To(Equal("JAVA_OPTS"))
+		Expect(distzip.Launch{Kind: distzip.LaunchKindJPackage}.OptionsVariable()).To(Equal("JAVA_TOOL_OPTIONS"))
+	})
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+type LaunchValidator struct {
+	Logger bard.Logger
+}
+
+// Validate checks that the classpath entries of the launch exist and that its main class, or the Main-Class of its
+// main jar, is present in the classpath, returning a description of each problem.  Entries containing unexpanded
+// variables and entries outside of the application, such as those appended with $BP_DIST_ZIP_CLASSPATH_APPEND, are
+// not validated.
+func (v LaunchValidator) Validate(launch Launch, applicationPath string) ([]string, error) {
+	if launch.Kind == LaunchKindNative || (len(launch.ClassPath) == 0 && launch.MainJar == "") {
+		return nil, nil
+	}
+
+	var (
+		problems  []string
+		classpath []string
+	)
+
+	check := func(entry string) {
+		rel, err := filepath.Rel(applicationPath, entry)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			v.Logger.Debugf("not validating classpath entry %s outside of the application", entry)
+			return
+		}
+		if strings.Contains(rel, "$") {
+			v.Logger.Debugf("not validating classpath entry %s with unexpanded variables", entry)
+			return
+		}
+
+		if _, err := os.Stat(strings.TrimSuffix(entry, string(filepath.Separator)+"*")); err != nil {
+			problems = append(problems, fmt.Sprintf("classpath entry %s does not exist%s", entry, alternatives(entry)))
+			return
+		}
+		classpath = append(classpath, entry)
+	}
+
+	for _, e := range launch.ClassPath {
+		check(e)
+	}
+
+	mainClass := launch.MainClass
+	if launch.MainJar != "" && mainClass == "" {
+		if _, err := os.Stat(launch.MainJar); err != nil {
+			problems = append(problems, fmt.Sprintf("main jar %s does not exist%s", launch.MainJar, alternatives(launch.MainJar)))
+			return problems, nil
+		}
+
+		m, err := ReadManifest(launch.MainJar)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read manifest of %s\n%w", launch.MainJar, err)
+		}
+
+		if mainClass = m["Main-Class"]; mainClass == "" {
+			return append(problems, fmt.Sprintf("main jar %s does not declare a Main-Class", launch.MainJar)), nil
+		}
+
+		classpath = []string{launch.MainJar}
+		for _, e := range strings.Fields(m["Class-Path"]) {
+			if !strings.Contains(e, ":") {
+				classpath = append(classpath, filepath.Join(filepath.Dir(launch.MainJar), filepath.FromSlash(e)))
+			}
+		}
+	}
+
+	if mainClass == "" || len(problems) > 0 {
+		return problems, nil
+	}
+
+	ok, err := containsClass(classpath, mainClass)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find main class %s\n%w", mainClass, err)
+	}
+	if !ok {
+		problems = append(problems, fmt.Sprintf("main class %s is not present in the classpath", mainClass))
+	}
+
+	return problems, nil
+}
+
+// containsClass returns true if a jar or directory of the classpath contains the class, including multi-release
+// versions of it.
+func containsClass(classpath []string, class string) (bool, error) {
+	name := strings.ReplaceAll(class, ".", "/") + ".class"
+	versioned := regexp.MustCompile(fmt.Sprintf(`^META-INF/versions/\d+/%s$`, regexp.QuoteMeta(name)))
+
+	var entries []string
+	for _, e := range classpath {
+		if strings.HasSuffix(e, string(filepath.Separator)+"*") {
+			jars, err := filepath.Glob(strings.TrimSuffix(e, "*") + "*.jar")
+			if err != nil {
+				return false, fmt.Errorf("unable to find jars in %s\n%w", e, err)
+			}
+			entries = append(entries, jars...)
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	for _, e := range entries {
+		info, err := os.Stat(e)
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			if _, err := os.Stat(filepath.Join(e, filepath.FromSlash(name))); err == nil {
+				return true, nil
+			}
+			continue
+		}
+
+		z, err := zip.OpenReader(e)
+		if err != nil {
+			continue
+		}
+		for _, f := range z.File {
+			if f.Name == name || versioned.MatchString(f.Name) {
+				z.Close()
+				return true, nil
+			}
+		}
+		z.Close()
+	}
+
+	return false, nil
+}
+
+// alternatives describes other versions of a missing versioned jar, such as foo-1.3.jar for foo-1.2.jar.
+func alternatives(file string) string {
+	m := artifactFileName.FindStringSubmatch(filepath.Base(file))
+	if m == nil {
+		return ""
+	}
+
+	candidates, err := filepath.Glob(filepath.Join(filepath.Dir(file), m[1]+"-*.jar"))
+	if err != nil || len(candidates) == 0 {
+		return ""
+	}
+
+	var names []string
+	for _, c := range candidates {
+		if n := artifactFileName.FindStringSubmatch(filepath.Base(c)); n != nil && n[1] == m[1] {
+			names = append(names, filepath.Base(c))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(", found %s", strings.Join(names, ", "))
+}
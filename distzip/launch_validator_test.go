@@ -0,0 +1,118 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testLaunchValidator(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+		v    distzip.LaunchValidator
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+
+		writeJar(t, filepath.Join(path, "lib", "app-1.0.jar"), map[string]string{"com/example/Main.class": ""})
+		writeJar(t, filepath.Join(path, "lib", "foo-1.3.jar"), map[string]string{})
+	})
+
+	it("accepts a valid launch", func() {
+		Expect(v.Validate(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			ClassPath: []string{filepath.Join(path, "lib", "app-1.0.jar"), filepath.Join(path, "lib", "foo-1.3.jar")},
+			MainClass: "com.example.Main",
+		}, path)).To(BeEmpty())
+	})
+
+	it("reports missing classpath entries with alternatives", func() {
+		Expect(v.Validate(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			ClassPath: []string{filepath.Join(path, "lib", "app-1.0.jar"), filepath.Join(path, "lib", "foo-1.2.jar"), filepath.Join(path, "lib", "bar-1.0.jar")},
+			MainClass: "com.example.Main",
+		}, path)).To(Equal([]string{
+			"classpath entry " + filepath.Join(path, "lib", "foo-1.2.jar") + " does not exist, found foo-1.3.jar",
+			"classpath entry " + filepath.Join(path, "lib", "bar-1.0.jar") + " does not exist",
+		}))
+	})
+
+	it("reports a missing main class", func() {
+		Expect(v.Validate(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			ClassPath: []string{filepath.Join(path, "lib", "*")},
+			MainClass: "com.example.Renamed",
+		}, path)).To(Equal([]string{"main class com.example.Renamed is not present in the classpath"}))
+	})
+
+	it("finds main classes in directories and multi-release jars", func() {
+		Expect(os.MkdirAll(filepath.Join(path, "classes", "com", "example"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(path, "classes", "com", "example", "Alpha.class"), []byte{}, 0644)).To(Succeed())
+		writeJar(t, filepath.Join(path, "lib", "bravo-1.0.jar"), map[string]string{"META-INF/versions/17/com/example/Bravo.class": ""})
+
+		l := distzip.Launch{Kind: distzip.LaunchKindScript, ClassPath: []string{filepath.Join(path, "classes"), filepath.Join(path, "lib", "bravo-1.0.jar")}}
+
+		l.MainClass = "com.example.Alpha"
+		Expect(v.Validate(l, path)).To(BeEmpty())
+
+		l.MainClass = "com.example.Bravo"
+		Expect(v.Validate(l, path)).To(BeEmpty())
+	})
+
+	it("checks the Main-Class of the main jar", func() {
+		writeJar(t, filepath.Join(path, "lib", "main-1.0.jar"), map[string]string{
+			"META-INF/MANIFEST.MF": "Main-Class: com.example.Main\nClass-Path: app-1.0.jar\n",
+		})
+		writeJar(t, filepath.Join(path, "lib", "broken-1.0.jar"), map[string]string{
+			"META-INF/MANIFEST.MF": "Main-Class: com.example.Broken\n",
+		})
+
+		Expect(v.Validate(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			ClassPath: []string{filepath.Join(path, "lib", "main-1.0.jar")},
+			MainJar:   filepath.Join(path, "lib", "main-1.0.jar"),
+		}, path)).To(BeEmpty())
+
+		Expect(v.Validate(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			ClassPath: []string{filepath.Join(path, "lib", "broken-1.0.jar")},
+			MainJar:   filepath.Join(path, "lib", "broken-1.0.jar"),
+		}, path)).To(Equal([]string{"main class com.example.Broken is not present in the classpath"}))
+	})
+
+	it("ignores entries outside of the application and with variables", func() {
+		Expect(v.Validate(distzip.Launch{
+			Kind:      distzip.LaunchKindScript,
+			ClassPath: []string{filepath.Join(path, "lib", "app-1.0.jar"), "/layers/agent/agent.jar", filepath.Join(path, "lib", "$EXTRA_JAR")},
+			MainClass: "com.example.Main",
+		}, path)).To(BeEmpty())
+	})
+
+	it("ignores native launchers", func() {
+		Expect(v.Validate(distzip.Launch{Kind: distzip.LaunchKindNative}, path)).To(BeEmpty())
+	})
+}
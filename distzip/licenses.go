@@ -0,0 +1,261 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// LicensesLabel is the image label pointing to the license report.
+const LicensesLabel = "io.paketo.dist-zip.licenses"
+
+type License struct {
+	Name   string `json:"name"`
+	URL    string `json:"url,omitempty"`
+	Source string `json:"source"`
+}
+
+// licenseTexts identifies common licenses by phrases in their text, in order of precedence.
+var licenseTexts = []struct {
+	Name    string
+	Phrases []string
+}{
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"EPL-2.0", []string{"eclipse public license", "2.0"}},
+	{"EPL-1.0", []string{"eclipse public license", "1.0"}},
+	{"LGPL", []string{"gnu lesser general public license"}},
+	{"GPL", []string{"gnu general public license"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"BSD", []string{"redistribution and use in source and binary forms"}},
+}
+
+// ReadLicenses returns the licenses declared by the <licenses> of the artifact's pom.xml, the Bundle-License
+// manifest header and META-INF/LICENSE* files of a jar.
+func ReadLicenses(artifact Artifact) ([]License, error) {
+	z, c, err := OpenJar(artifact.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var licenses []License
+	for _, f := range z.File {
+		switch {
+		case strings.HasPrefix(f.Name, "META-INF/maven/") && path.Base(f.Name) == "pom.xml":
+			if artifact.GroupID != "" && f.Name != fmt.Sprintf("META-INF/maven/%s/%s/pom.xml", artifact.GroupID, artifact.ArtifactID) {
+				continue
+			}
+
+			var pom struct {
+				Licenses []struct {
+					Name string `xml:"name"`
+					URL  string `xml:"url"`
+				} `xml:"licenses>license"`
+			}
+			if err := readZipEntry(f, func(in io.Reader) error { return xml.NewDecoder(in).Decode(&pom) }); err != nil {
+				return nil, fmt.Errorf("unable to decode %s in %s\n%w", f.Name, artifact.Path, err)
+			}
+
+			for _, l := range pom.Licenses {
+				licenses = append(licenses, License{Name: strings.TrimSpace(l.Name), URL: strings.TrimSpace(l.URL), Source: f.Name})
+			}
+
+		case f.Name == "META-INF/MANIFEST.MF":
+			var m map[string]string
+			if err := readZipEntry(f, func(in io.Reader) (err error) { m, err = parseManifest(in); return err }); err != nil {
+				return nil, fmt.Errorf("unable to parse %s in %s\n%w", f.Name, artifact.Path, err)
+			}
+
+			for _, l := range strings.Split(m["Bundle-License"], ",") {
+				name, _, _ := strings.Cut(strings.TrimSpace(l), ";")
+				if name == "" {
+					continue
+				}
+				if strings.Contains(name, "://") {
+					licenses = append(licenses, License{Name: name, URL: name, Source: "Bundle-License"})
+				} else {
+					licenses = append(licenses, License{Name: name, Source: "Bundle-License"})
+				}
+			}
+
+		case path.Dir(f.Name) == "META-INF" && strings.HasPrefix(strings.ToUpper(path.Base(f.Name)), "LICENSE"):
+			var b []byte
+			if err := readZipEntry(f, func(in io.Reader) (err error) { b, err = io.ReadAll(in); return err }); err != nil {
+				return nil, fmt.Errorf("unable to read %s in %s\n%w", f.Name, artifact.Path, err)
+			}
+
+			licenses = append(licenses, License{Name: identifyLicense(string(b)), Source: f.Name})
+		}
+	}
+
+	return licenses, nil
+}
+
+func readZipEntry(f *zip.File, read func(in io.Reader) error) error {
+	in, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return read(in)
+}
+
+func identifyLicense(text string) string {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	for _, l := range licenseTexts {
+		found := true
+		for _, p := range l.Phrases {
+			found = found && strings.Contains(text, p)
+		}
+		if found {
+			return l.Name
+		}
+	}
+
+	return "unidentified"
+}
+
+type LicensedArtifact struct {
+	Path        string    `json:"path"`
+	Coordinates string    `json:"coordinates"`
+	Licenses    []License `json:"licenses"`
+}
+
+type LicenseReport struct {
+	Artifacts []LicensedArtifact `json:"artifacts"`
+}
+
+// NewLicenseReport reads the licenses of artifacts, reporting their paths relative to the application.
+func NewLicenseReport(applicationPath string, artifacts []Artifact) (LicenseReport, error) {
+	report := LicenseReport{Artifacts: []LicensedArtifact{}}
+
+	for _, a := range artifacts {
+		licenses, err := ReadLicenses(a)
+		if err != nil {
+			return LicenseReport{}, fmt.Errorf("unable to read licenses of %s\n%w", a.Path, err)
+		}
+
+		p := a.Path
+		if rel, err := filepath.Rel(applicationPath, a.Path); err == nil {
+			p = rel
+		}
+
+		if licenses == nil {
+			licenses = []License{}
+		}
+		report.Artifacts = append(report.Artifacts, LicensedArtifact{Path: p, Coordinates: a.String(), Licenses: licenses})
+	}
+
+	return report, nil
+}
+
+// Summary returns the number of artifacts under each license and the artifacts without licenses.
+func (r LicenseReport) Summary() string {
+	var (
+		counts     = map[string]int{}
+		unlicensed []string
+	)
+
+	for _, a := range r.Artifacts {
+		if len(a.Licenses) == 0 {
+			unlicensed = append(unlicensed, a.Path)
+			continue
+		}
+
+		names := map[string]bool{}
+		for _, l := range a.Licenses {
+			names[l.Name] = true
+		}
+		for n := range names {
+			counts[n]++
+		}
+	}
+
+	var names []string
+	for n := range counts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, n := range names {
+		sb.WriteString(fmt.Sprintf("%s: %d\n", n, counts[n]))
+	}
+	if len(unlicensed) > 0 {
+		sb.WriteString(fmt.Sprintf("no license found: %d\n", len(unlicensed)))
+		for _, u := range unlicensed {
+			sb.WriteString(fmt.Sprintf("  %s\n", u))
+		}
+	}
+
+	return sb.String()
+}
+
+type Licenses struct {
+	LayerContributor libpak.LayerContributor
+	Logger           bard.Logger
+	Report           LicenseReport
+}
+
+func NewLicenses(report LicenseReport) Licenses {
+	return Licenses{
+		LayerContributor: libpak.NewLayerContributor("License Report", map[string]interface{}{"report": report}, libcnb.LayerTypes{Launch: true}),
+		Report:           report,
+	}
+}
+
+func (l Licenses) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	l.LayerContributor.Logger = l.Logger
+
+	return l.LayerContributor.Contribute(layer, func() (libcnb.Layer, error) {
+		b, err := json.MarshalIndent(l.Report, "", "  ")
+		if err != nil {
+			return libcnb.Layer{}, fmt.Errorf("unable to encode license report\n%w", err)
+		}
+
+		file := filepath.Join(layer.Path, "licenses.json")
+		if err := os.WriteFile(file, b, 0644); err != nil {
+			return libcnb.Layer{}, fmt.Errorf("unable to write %s\n%w", file, err)
+		}
+
+		file = filepath.Join(layer.Path, "licenses.txt")
+		if err := os.WriteFile(file, []byte(l.Report.Summary()), 0644); err != nil {
+			return libcnb.Layer{}, fmt.Errorf("unable to write %s\n%w", file, err)
+		}
+
+		return layer, nil
+	})
+}
+
+func (Licenses) Name() string {
+	return "licenses"
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testLicenses(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	it("reads licenses from pom.xml, Bundle-License and LICENSE files", func() {
+		writeJar(t, filepath.Join(path, "alpha-1.0.jar"), map[string]string{
+			"META-INF/MANIFEST.MF": "Bundle-License: EPL-2.0;link=\"https://www.eclipse.org/legal/epl-2.0\", https://opensource.org/licenses/MIT\n",
+			"META-INF/maven/com.example/alpha/pom.xml": `<project>
+  <licenses>
+    <license>
+      <name>The Apache Software License, Version 2.0</name>
+      <url>https://www.apache.org/licenses/LICENSE-2.0.txt</url>
+    </license>
+  </licenses>
+</project>`,
+			"META-INF/maven/com.example/shaded/pom.xml": "<project><licenses><license><name>GPL</name></license></licenses></project>",
+			"META-INF/LICENSE.txt":                      "Permission is hereby granted,\n free of charge, to any person",
+		})
+
+		Expect(distzip.ReadLicenses(distzip.Artifact{
+			Path: filepath.Join(path, "alpha-1.0.jar"), GroupID: "com.example", ArtifactID: "alpha", Version: "1.0",
+		})).To(ConsistOf(
+			distzip.License{Name: "The Apache Software License, Version 2.0", URL: "https://www.apache.org/licenses/LICENSE-2.0.txt", Source: "META-INF/maven/com.example/alpha/pom.xml"},
+			distzip.License{Name: "EPL-2.0", Source: "Bundle-License"},
+			distzip.License{Name: "https://opensource.org/licenses/MIT", URL: "https://opensource.org/licenses/MIT", Source: "Bundle-License"},
+			distzip.License{Name: "MIT", Source: "META-INF/LICENSE.txt"},
+		))
+	})
+
+	context("LicenseReport", func() {
+		var report distzip.LicenseReport
+
+		it.Before(func() {
+			writeJar(t, filepath.Join(path, "lib", "alpha-1.0.jar"), map[string]string{
+				"META-INF/LICENSE": "Apache License\nVersion 2.0, January 2004",
+			})
+			writeJar(t, filepath.Join(path, "lib", "bravo-2.0.jar"), map[string]string{
+				"META-INF/LICENSE": "Apache License\nVersion 2.0, January 2004",
+			})
+			writeJar(t, filepath.Join(path, "lib", "charlie-3.0.jar"), map[string]string{})
+
+			var err error
+			report, err = distzip.NewLicenseReport(path, []distzip.Artifact{
+				{Path: filepath.Join(path, "lib", "alpha-1.0.jar"), ArtifactID: "alpha", Version: "1.0"},
+				{Path: filepath.Join(path, "lib", "bravo-2.0.jar"), ArtifactID: "bravo", Version: "2.0"},
+				{Path: filepath.Join(path, "lib", "charlie-3.0.jar"), ArtifactID: "charlie", Version: "3.0"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("describes artifacts relative to the application", func() {
+			Expect(report.Artifacts).To(Equal([]distzip.LicensedArtifact{
+				{Path: filepath.Join("lib", "alpha-1.0.jar"), Coordinates: "alpha:1.0", Licenses: []distzip.License{{Name: "Apache-2.0", Source: "META-INF/LICENSE"}}},
+				{Path: filepath.Join("lib", "bravo-2.0.jar"), Coordinates: "bravo:2.0", Licenses: []distzip.License{{Name: "Apache-2.0", Source: "META-INF/LICENSE"}}},
+				{Path: filepath.Join("lib", "charlie-3.0.jar"), Coordinates: "charlie:3.0", Licenses: []distzip.License{}},
+			}))
+		})
+
+		it("summarizes licenses", func() {
+			Expect(report.Summary()).To(Equal("Apache-2.0: 2\nno license found: 1\n  lib/charlie-3.0.jar\n"))
+		})
+
+		it("contributes report", func() {
+			ctx := libcnb.BuildContext{Layers: libcnb.Layers{Path: t.TempDir()}}
+
+			layer, err := ctx.Layers.Layer("test-layer")
+			Expect(err).NotTo(HaveOccurred())
+
+			layer, err = distzip.NewLicenses(report).Contribute(layer)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(layer.Launch).To(BeTrue())
+
+			b, err := os.ReadFile(filepath.Join(layer.Path, "licenses.json"))
+			Expect(err).NotTo(HaveOccurred())
+			var actual distzip.LicenseReport
+			Expect(json.Unmarshal(b, &actual)).To(Succeed())
+			Expect(actual).To(Equal(report))
+
+			Expect(os.ReadFile(filepath.Join(layer.Path, "licenses.txt"))).To(Equal([]byte(report.Summary())))
+		})
+	})
+}
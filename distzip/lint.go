@@ -0,0 +1,132 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type LintLevel string
+
+const (
+	LintLevelOff   LintLevel = "off"
+	LintLevelWarn  LintLevel = "warn"
+	LintLevelError LintLevel = "error"
+)
+
+// ParseLintLevel parses the level of a lint rule, defaulting to warn if s is empty.
+func ParseLintLevel(s string) (LintLevel, error) {
+	switch l := LintLevel(strings.ToLower(s)); l {
+	case "":
+		return LintLevelWarn, nil
+	case LintLevelOff, LintLevelWarn, LintLevelError:
+		return l, nil
+	default:
+		return "", fmt.Errorf("invalid lint level %s, expected off, warn or error", s)
+	}
+}
+
+// TestArtifactGroups are the groupIds of artifacts only used by tests.
+var TestArtifactGroups = []string{
+	"io.rest-assured",
+	"junit",
+	"org.assertj",
+	"org.junit",
+	"org.mockito",
+	"org.spockframework",
+	"org.testcontainers",
+	"org.testng",
+}
+
+// TestArtifactNames are the artifactId prefixes of artifacts only used by tests, for jars without Maven metadata.
+var TestArtifactNames = []string{
+	"assertj-",
+	"junit",
+	"mockito-",
+	"spock-",
+	"testng",
+}
+
+type Linter struct {
+	Duplicates    LintLevel
+	Snapshots     LintLevel
+	TestArtifacts LintLevel
+}
+
+// Lint checks artifacts for several versions of the same artifact, test-only artifacts and snapshot versions,
+// returning the findings of rules at warn and at error level.
+func (l Linter) Lint(artifacts []Artifact) ([]string, []string) {
+	findings := map[LintLevel][]string{}
+
+	versions := map[string][]Artifact{}
+	for _, a := range artifacts {
+		versions[a.Coordinates()] = append(versions[a.Coordinates()], a)
+
+		if isTestArtifact(a) {
+			findings[l.TestArtifacts] = append(findings[l.TestArtifacts],
+				fmt.Sprintf("%s is a test-only artifact (%s)", a, filepath.Base(a.Path)))
+		}
+
+		if strings.HasSuffix(a.Version, "-SNAPSHOT") {
+			findings[l.Snapshots] = append(findings[l.Snapshots],
+				fmt.Sprintf("%s is a snapshot version (%s)", a, filepath.Base(a.Path)))
+		}
+	}
+
+	var coordinates []string
+	for c, artifacts := range versions {
+		distinct := map[string]bool{}
+		for _, a := range artifacts {
+			distinct[a.Version] = true
+		}
+		if len(distinct) > 1 {
+			coordinates = append(coordinates, c)
+		}
+	}
+	sort.Strings(coordinates)
+
+	for _, c := range coordinates {
+		var s []string
+		for _, a := range versions[c] {
+			s = append(s, fmt.Sprintf("%s (%s)", a.Version, filepath.Base(a.Path)))
+		}
+		findings[l.Duplicates] = append(findings[l.Duplicates], fmt.Sprintf("%s has several versions: %s", c, strings.Join(s, ", ")))
+	}
+
+	return findings[LintLevelWarn], findings[LintLevelError]
+}
+
+func isTestArtifact(a Artifact) bool {
+	if a.GroupID == "" {
+		for _, n := range TestArtifactNames {
+			if strings.HasPrefix(a.ArtifactID, n) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, g := range TestArtifactGroups {
+		if a.GroupID == g || strings.HasPrefix(a.GroupID, g+".") {
+			return true
+		}
+	}
+	return false
+}
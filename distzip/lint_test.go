@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testLint(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		artifacts = []distzip.Artifact{
+			{Path: "/workspace/lib/guava-31.0-jre.jar", GroupID: "com.google.guava", ArtifactID: "guava", Version: "31.0-jre"},
+			{Path: "/workspace/lib/guava-33.0-jre.jar", GroupID: "com.google.guava", ArtifactID: "guava", Version: "33.0-jre"},
+			{Path: "/workspace/lib/junit-jupiter-api-5.10.0.jar", GroupID: "org.junit.jupiter", ArtifactID: "junit-jupiter-api", Version: "5.10.0"},
+			{Path: "/workspace/lib/mockito-core-5.8.0.jar", ArtifactID: "mockito-core", Version: "5.8.0"},
+			{Path: "/workspace/lib/core-1.0-SNAPSHOT.jar", GroupID: "com.example", ArtifactID: "core", Version: "1.0-SNAPSHOT"},
+			{Path: "/workspace/lib/junit-platform-commons-ext-1.0.jar", GroupID: "com.example", ArtifactID: "junit-platform-commons-ext", Version: "1.0"},
+		}
+	)
+
+	it("parses lint levels", func() {
+		Expect(distzip.ParseLintLevel("")).To(Equal(distzip.LintLevelWarn))
+		Expect(distzip.ParseLintLevel("OFF")).To(Equal(distzip.LintLevelOff))
+		Expect(distzip.ParseLintLevel("error")).To(Equal(distzip.LintLevelError))
+
+		_, err := distzip.ParseLintLevel("fatal")
+		Expect(err).To(MatchError("invalid lint level fatal, expected off, warn or error"))
+	})
+
+	it("returns findings by level", func() {
+		warnings, errors := distzip.Linter{
+			Duplicates:    distzip.LintLevelError,
+			Snapshots:     distzip.LintLevelWarn,
+			TestArtifacts: distzip.LintLevelWarn,
+		}.Lint(artifacts)
+
+		Expect(warnings).To(Equal([]string{
+			"org.junit.jupiter:junit-jupiter-api:5.10.0 is a test-only artifact (junit-jupiter-api-5.10.0.jar)",
+			"mockito-core:5.8.0 is a test-only artifact (mockito-core-5.8.0.jar)",
+			"com.example:core:1.0-SNAPSHOT is a snapshot version (core-1.0-SNAPSHOT.jar)",
+		}))
+		Expect(errors).To(Equal([]string{
+			"com.google.guava:guava has several versions: 31.0-jre (guava-31.0-jre.jar), 33.0-jre (guava-33.0-jre.jar)",
+		}))
+	})
+
+	it("ignores rules that are off", func() {
+		warnings, errors := distzip.Linter{
+			Duplicates:    distzip.LintLevelOff,
+			Snapshots:     distzip.LintLevelOff,
+			TestArtifacts: distzip.LintLevelOff,
+		}.Lint(artifacts)
+
+		Expect(warnings).To(BeEmpty())
+		Expect(errors).To(BeEmpty())
+	})
+
+	it("does not report several jars of the same version", func() {
+		warnings, errors := distzip.Linter{Duplicates: distzip.LintLevelError}.Lint([]distzip.Artifact{
+			{Path: "lib/netty-transport-native-epoll-4.1.100.Final-linux-x86_64.jar", GroupID: "io.netty", ArtifactID: "netty-transport-native-epoll", Version: "4.1.100.Final"},
+			{Path: "lib/netty-transport-native-epoll-4.1.100.Final-linux-aarch_64.jar", GroupID: "io.netty", ArtifactID: "netty-transport-native-epoll", Version: "4.1.100.Final"},
+		})
+
+		Expect(warnings).To(BeEmpty())
+		Expect(errors).To(BeEmpty())
+	})
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// memoryFlag matches the JVM options that are calculated by the memory calculator at launch.
+var memoryFlag = regexp.MustCompile(`^-(?:Xm[sx]\S+|Xss\S+|XX:(?:MaxRAM|MaxRAMPercentage|InitialRAMPercentage|MinRAMPercentage|MaxMetaspaceSize|MetaspaceSize|ReservedCodeCacheSize|MaxDirectMemorySize)=\S+)$`)
+
+// MemoryFlags returns the options that conflict with the memory calculator, such as -Xmx2g or -Xss1m.
+func MemoryFlags(options []string) []string {
+	var flags []string
+	for _, o := range options {
+		if memoryFlag.MatchString(o) {
+			flags = append(flags, o)
+		}
+	}
+	return flags
+}
+
+type MemoryFlagStripper struct {
+	Logger bard.Logger
+}
+
+// Strip removes memory flags from the DEFAULT_JVM_OPTS of a start script or the java-options of a jpackage
+// configuration and from the JVM options of the launch, returning the updated launch.
+func (m MemoryFlagStripper) Strip(launch Launch) (Launch, error) {
+	flags := MemoryFlags(launch.JVMOptions)
+	if len(flags) == 0 {
+		return launch, nil
+	}
+
+	var (
+		path string
+		err  error
+	)
+	switch launch.Kind {
+	case LaunchKindScript:
+		path = launch.Launcher
+		err = rewriteLines(path, func(line string) (string, bool) {
+			if !strings.HasPrefix(line, "DEFAULT_JVM_OPTS=") {
+				return line, true
+			}
+			for _, f := range flags {
+				line = removeOption(line, f)
+			}
+			return line, true
+		})
+	case LaunchKindJPackage:
+		path = filepath.Join(launch.Home, "lib", "app", fmt.Sprintf("%s.cfg", filepath.Base(launch.Launcher)))
+		err = rewriteLines(path, func(line string) (string, bool) {
+			value, ok := strings.CutPrefix(strings.TrimSpace(line), "java-options=")
+			return line, !ok || !memoryFlag.MatchString(value)
+		})
+	default:
+		return launch, nil
+	}
+	if err != nil {
+		return Launch{}, fmt.Errorf("unable to rewrite %s\n%w", path, err)
+	}
+
+	for _, f := range flags {
+		m.Logger.Bodyf("Removed %s from %s", f, path)
+	}
+
+	launch.JVMOptions = slices.DeleteFunc(slices.Clone(launch.JVMOptions), memoryFlag.MatchString)
+	return launch, nil
+}
+
+// rewriteLines replaces each line of the file at path with the result of f, dropping lines for which f returns false.
+func rewriteLines(path string, f func(line string) (string, bool)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("unable to stat %s\n%w", path, err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+
+	var lines []string
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		eol := ""
+		if strings.HasSuffix(line, "\n") {
+			line, eol = strings.TrimSuffix(line, "\n"), "\n"
+		}
+
+		if line, ok := f(line); ok {
+			lines = append(lines, line+eol)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("unable to write %s\n%w", path, err)
+	}
+
+	return nil
+}
+
+// removeOption removes the first, possibly double quoted, occurrence of option and its separating whitespace from line.
+func removeOption(line string, option string) string {
+	for _, o := range []string{
+		fmt.Sprintf(` "%s"`, option), fmt.Sprintf(`"%s" `, option), fmt.Sprintf(`"%s"`, option),
+		fmt.Sprintf(` %s`, option), fmt.Sprintf(`%s `, option), option,
+	} {
+		if i := strings.Index(line, o); i >= 0 {
+			return line[:i] + line[i+len(o):]
+		}
+	}
+	return line
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+func testMemoryFlags(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	it("returns memory flags", func() {
+		Expect(distzip.MemoryFlags([]string{
+			"-Xmx2g", "-Xms512m", "-Xss1m", "-XX:MaxMetaspaceSize=256m", "-XX:+UseG1GC", "-Dapp.home=/workspace", "-Xshare:auto",
+		})).To(Equal([]string{"-Xmx2g", "-Xms512m", "-Xss1m", "-XX:MaxMetaspaceSize=256m"}))
+	})
+
+	context("MemoryFlagStripper", func() {
+		var (
+			buf *bytes.Buffer
+			m   distzip.MemoryFlagStripper
+		)
+
+		it.Before(func() {
+			buf = &bytes.Buffer{}
+			m.Logger = bard.NewLogger(buf)
+		})
+
+		it("removes memory flags from DEFAULT_JVM_OPTS of a script", func() {
+			script := filepath.Join(path, "bin", "app")
+			Expect(os.MkdirAll(filepath.Dir(script), 0755)).To(Succeed())
+			Expect(os.WriteFile(script, []byte(`#!/bin/sh
+DEFAULT_JVM_OPTS='"-Xmx2g" "-Dapp.home=$APP_HOME" "-Xss1m"'
+exec "$JAVACMD" -Xmx1g "$@"
+`), 0755)).To(Succeed())
+
+			l, err := m.Strip(distzip.Launch{
+				Kind:       distzip.LaunchKindScript,
+				Launcher:   script,
+				Home:       path,
+				JVMOptions: []string{"-Xmx2g", "-Dapp.home=" + path, "-Xss1m"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l.JVMOptions).To(Equal([]string{"-Dapp.home=" + path}))
+			Expect(os.ReadFile(script)).To(Equal([]byte(`#!/bin/sh
+DEFAULT_JVM_OPTS='"-Dapp.home=$APP_HOME"'
+exec "$JAVACMD" -Xmx1g "$@"
+`)))
+			Expect(os.Stat(script)).To(HaveField("Mode()", os.FileMode(0755)))
+			Expect(buf.String()).To(ContainSubstring("Removed -Xmx2g from %s", script))
+			Expect(buf.String()).To(ContainSubstring("Removed -Xss1m from %s", script))
+		})
+
+		it("removes memory flags from java-options of a jpackage configuration", func() {
+			cfg := filepath.Join(path, "lib", "app", "app.cfg")
+			Expect(os.MkdirAll(filepath.Dir(cfg), 0755)).To(Succeed())
+			Expect(os.WriteFile(cfg, []byte(`[JavaOptions]
+java-options=-Xmx2g
+java-options=-Dapp.home=$APPDIR
+`), 0644)).To(Succeed())
+
+			l, err := m.Strip(distzip.Launch{
+				Kind:       distzip.LaunchKindJPackage,
+				Launcher:   filepath.Join(path, "bin", "app"),
+				Home:       path,
+				JVMOptions: []string{"-Xmx2g", "-Dapp.home=" + filepath.Dir(cfg)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(l.JVMOptions).To(Equal([]string{"-Dapp.home=" + filepath.Dir(cfg)}))
+			Expect(os.ReadFile(cfg)).To(Equal([]byte(`[JavaOptions]
+java-options=-Dapp.home=$APPDIR
+`)))
+		})
+
+		it("does nothing without memory flags", func() {
+			l := distzip.Launch{Kind: distzip.LaunchKindScript, Launcher: filepath.Join(path, "bin", "app"), JVMOptions: []string{"-Dalpha=bravo"}}
+
+			Expect(m.Strip(l)).To(Equal(l))
+		})
+	})
+}
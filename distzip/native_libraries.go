@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// ArchitectureAliases are the names used for an architecture in native library directory names.
+var ArchitectureAliases = map[string][]string{
+	"amd64": {"amd64", "x86_64", "x86-64", "x64"},
+	"arm64": {"arm64", "aarch64"},
+}
+
+type NativeLibraryResolver struct {
+	Home   string
+	Logger bard.Logger
+}
+
+// Resolve returns the directories below the distribution's lib directory named linux-<arch> for an alias of arch,
+// such as lib/native/linux-x86_64 or lib/linux-aarch64.
+func (n NativeLibraryResolver) Resolve(arch string) ([]string, error) {
+	names := map[string]bool{}
+	for _, a := range append([]string{arch}, ArchitectureAliases[arch]...) {
+		names[fmt.Sprintf("linux-%s", a)] = true
+	}
+
+	root := filepath.Join(n.Home, "lib")
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && names[strings.ToLower(d.Name())] {
+			n.Logger.Debugf("found native libraries for %s in %s", arch, path)
+			paths = append(paths, path)
+			return fs.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find native libraries in %s\n%w", root, err)
+	}
+
+	return paths, nil
+}
+
+type NativeLibraries struct {
+	LayerContributor libpak.LayerContributor
+	Logger           bard.Logger
+	Paths            []string
+
+	// JavaToolOptions indicates whether java.library.path should be configured through $JAVA_TOOL_OPTIONS.
+	JavaToolOptions bool
+}
+
+func NewNativeLibraries(paths []string, javaToolOptions bool) NativeLibraries {
+	expected := map[string]interface{}{"paths": paths, "java-tool-options": javaToolOptions}
+
+	return NativeLibraries{
+		LayerContributor: libpak.NewLayerContributor("Native Libraries", expected, libcnb.LayerTypes{Launch: true}),
+		Paths:            paths,
+		JavaToolOptions:  javaToolOptions,
+	}
+}
+
+func (n NativeLibraries) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	n.LayerContributor.Logger = n.Logger
+
+	return n.LayerContributor.Contribute(layer, func() (libcnb.Layer, error) {
+		layer.LaunchEnvironment.Prepend("LD_LIBRARY_PATH", string(filepath.ListSeparator), strings.Join(n.Paths, string(filepath.ListSeparator)))
+
+		if n.JavaToolOptions {
+			layer.LaunchEnvironment.Appendf("JAVA_TOOL_OPTIONS", " ", "-Djava.library.path=%s", strings.Join(n.Paths, string(filepath.ListSeparator)))
+		}
+
+		return layer, nil
+	})
+}
+
+func (NativeLibraries) Name() string {
+	return "native-libraries"
+}
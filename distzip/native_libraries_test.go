@@ -0,0 +1,105 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testNativeLibraries(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	context("NativeLibraryResolver", func() {
+		var r distzip.NativeLibraryResolver
+
+		it.Before(func() {
+			r.Home = t.TempDir()
+
+			Expect(os.MkdirAll(filepath.Join(r.Home, "lib", "native", "linux-x86_64"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.Home, "lib", "native", "linux-aarch64"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.Home, "lib", "linux-arm64"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.Home, "lib", "darwin-aarch64"), 0755)).To(Succeed())
+		})
+
+		it("returns directories for amd64", func() {
+			Expect(r.Resolve("amd64")).To(Equal([]string{
+				filepath.Join(r.Home, "lib", "native", "linux-x86_64"),
+			}))
+		})
+
+		it("returns directories for arm64", func() {
+			Expect(r.Resolve("arm64")).To(Equal([]string{
+				filepath.Join(r.Home, "lib", "linux-arm64"),
+				filepath.Join(r.Home, "lib", "native", "linux-aarch64"),
+			}))
+		})
+
+		it("returns nothing without a lib directory", func() {
+			r.Home = t.TempDir()
+
+			Expect(r.Resolve("amd64")).To(BeEmpty())
+		})
+	})
+
+	context("NativeLibraries", func() {
+		var (
+			ctx   libcnb.BuildContext
+			paths []string
+		)
+
+		it.Before(func() {
+			ctx.Layers.Path = t.TempDir()
+			paths = []string{"/workspace/app/lib/linux-x86_64", "/workspace/app/lib/native/linux-x86_64"}
+		})
+
+		it("contributes library path environment", func() {
+			layer, err := ctx.Layers.Layer("test-layer")
+			Expect(err).NotTo(HaveOccurred())
+
+			layer, err = distzip.NewNativeLibraries(paths, true).Contribute(layer)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(layer.Launch).To(BeTrue())
+			Expect(layer.LaunchEnvironment).To(HaveKeyWithValue("LD_LIBRARY_PATH.prepend",
+				"/workspace/app/lib/linux-x86_64:/workspace/app/lib/native/linux-x86_64"))
+			Expect(layer.LaunchEnvironment).To(HaveKeyWithValue("JAVA_TOOL_OPTIONS.append",
+				"-Djava.library.path=/workspace/app/lib/linux-x86_64:/workspace/app/lib/native/linux-x86_64"))
+			Expect(layer.LaunchEnvironment).To(HaveKeyWithValue("JAVA_TOOL_OPTIONS.delim", " "))
+		})
+
+		it("does not configure $JAVA_TOOL_OPTIONS when disabled", func() {
+			layer, err := ctx.Layers.Layer("test-layer")
+			Expect(err).NotTo(HaveOccurred())
+
+			layer, err = distzip.NewNativeLibraries(paths, false).Contribute(layer)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(layer.LaunchEnvironment).To(HaveKey("LD_LIBRARY_PATH.prepend"))
+			Expect(layer.LaunchEnvironment).NotTo(HaveKey("JAVA_TOOL_OPTIONS.append"))
+		})
+	})
+}
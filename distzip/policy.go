@@ -0,0 +1,151 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver/v3"
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak/bard"
+	"github.com/paketo-buildpacks/libpak/bindings"
+)
+
+const (
+	// PolicyBindingType is the type of bindings providing a policy.toml.
+	PolicyBindingType = "dist-zip-policy"
+
+	// PolicyBindingKey is the key of the policy file in a policy binding.
+	PolicyBindingKey = "policy.toml"
+)
+
+// DenyRule denies artifacts matching Artifact, a groupId:artifactId pattern such as org.apache.logging.log4j:*, in
+// versions matching the Versions constraint, such as < 2.17.1, or in all versions if Versions is empty.
+type DenyRule struct {
+	Artifact string `toml:"artifact"`
+	Versions string `toml:"versions"`
+	Reason   string `toml:"reason"`
+}
+
+// Matches returns whether the coordinates of artifact match the rule.  Artifacts identified only by their file name,
+// without a groupId, are matched against the artifactId part of the rule, unless it is * and the rule only denies a
+// group.
+func (r DenyRule) Matches(artifact Artifact) bool {
+	pattern := r.Artifact
+	if artifact.GroupID == "" {
+		if _, id, ok := strings.Cut(pattern, ":"); ok {
+			if id == "*" {
+				return false
+			}
+			pattern = id
+		}
+	}
+
+	ok, _ := path.Match(pattern, artifact.Coordinates())
+	return ok
+}
+
+type Policy struct {
+	Deny []DenyRule `toml:"deny"`
+}
+
+// PolicyViolation is an artifact denied by a rule.
+type PolicyViolation struct {
+	Artifact Artifact
+	Rule     DenyRule
+}
+
+type PolicyResolver struct {
+	ApplicationPath string
+	Bindings        libcnb.Bindings
+	Logger          bard.Logger
+}
+
+// Resolve reads the policy from a binding of type dist-zip-policy, or from file, relative to the application, if it
+// is not empty.  It returns false if there is neither.
+func (p PolicyResolver) Resolve(file string) (Policy, string, bool, error) {
+	b, ok, err := bindings.ResolveOne(p.Bindings, bindings.OfType(PolicyBindingType))
+	if err != nil {
+		return Policy{}, "", false, fmt.Errorf("unable to resolve binding %s\n%w", PolicyBindingType, err)
+	}
+
+	if ok {
+		s, ok := b.SecretFilePath(PolicyBindingKey)
+		if !ok {
+			return Policy{}, "", false, fmt.Errorf("binding %s does not contain %s", b.Name, PolicyBindingKey)
+		}
+		file = s
+	} else if file == "" {
+		return Policy{}, "", false, nil
+	} else if !filepath.IsAbs(file) {
+		file = filepath.Join(p.ApplicationPath, file)
+	}
+
+	var policy Policy
+	if _, err := toml.DecodeFile(file, &policy); err != nil {
+		return Policy{}, "", false, fmt.Errorf("unable to decode policy %s\n%w", file, err)
+	}
+
+	for _, r := range policy.Deny {
+		if _, err := path.Match(r.Artifact, ""); err != nil {
+			return Policy{}, "", false, fmt.Errorf("invalid artifact %s in policy %s\n%w", r.Artifact, file, err)
+		}
+		if r.Versions != "" {
+			if _, err := semver.NewConstraint(r.Versions); err != nil {
+				return Policy{}, "", false, fmt.Errorf("invalid versions %s of %s in policy %s\n%w", r.Versions, r.Artifact, file, err)
+			}
+		}
+	}
+
+	return policy, file, true, nil
+}
+
+// Evaluate returns the artifacts denied by the rules of the policy and the artifacts matching a rule with a version
+// constraint whose version cannot be compared with it.  Versions are compared as semantic versions with qualifiers,
+// such as 33.0-jre, as pre-releases.
+func (p Policy) Evaluate(artifacts []Artifact) ([]PolicyViolation, []PolicyViolation) {
+	var violations, unverified []PolicyViolation
+
+	for _, a := range artifacts {
+		for _, r := range p.Deny {
+			if !r.Matches(a) {
+				continue
+			}
+
+			if r.Versions != "" {
+				c, _ := semver.NewConstraint(r.Versions)
+				c.IncludePrerelease = true
+				v, err := semver.NewVersion(a.Version)
+				if err != nil {
+					unverified = append(unverified, PolicyViolation{Artifact: a, Rule: r})
+					continue
+				}
+				if !c.Check(v) {
+					continue
+				}
+			}
+
+			violations = append(violations, PolicyViolation{Artifact: a, Rule: r})
+		}
+	}
+
+	return violations, unverified
+}
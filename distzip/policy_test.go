@@ -0,0 +1,135 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testPolicy(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+		r    distzip.PolicyResolver
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+		r.ApplicationPath = path
+	})
+
+	context("PolicyResolver", func() {
+		it("returns false without a policy", func() {
+			_, _, ok, err := r.Resolve("")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("reads a policy from the application", func() {
+			Expect(os.WriteFile(filepath.Join(path, "policy.toml"), []byte(`
+[[deny]]
+artifact = "org.apache.logging.log4j:log4j-core"
+versions = "< 2.17.1"
+reason   = "CVE-2021-44228"
+`), 0644)).To(Succeed())
+
+			policy, file, ok, err := r.Resolve("policy.toml")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(file).To(Equal(filepath.Join(path, "policy.toml")))
+			Expect(policy).To(Equal(distzip.Policy{Deny: []distzip.DenyRule{
+				{Artifact: "org.apache.logging.log4j:log4j-core", Versions: "< 2.17.1", Reason: "CVE-2021-44228"},
+			}}))
+		})
+
+		it("prefers a policy from a binding", func() {
+			binding := t.TempDir()
+			Expect(os.WriteFile(filepath.Join(binding, "policy.toml"), []byte("[[deny]]\nartifact = \"junit:junit\"\n"), 0644)).To(Succeed())
+			r.Bindings = libcnb.Bindings{
+				{Name: "policy", Type: "dist-zip-policy", Path: binding, Secret: map[string]string{"policy.toml": ""}},
+			}
+
+			policy, file, ok, err := r.Resolve("policy.toml")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(file).To(Equal(filepath.Join(binding, "policy.toml")))
+			Expect(policy.Deny).To(Equal([]distzip.DenyRule{{Artifact: "junit:junit"}}))
+		})
+
+		it("fails with an invalid version constraint", func() {
+			Expect(os.WriteFile(filepath.Join(path, "policy.toml"), []byte("[[deny]]\nartifact = \"junit:junit\"\nversions = \"before 5\"\n"), 0644)).To(Succeed())
+
+			_, _, _, err := r.Resolve("policy.toml")
+			Expect(err).To(MatchError(ContainSubstring("invalid versions before 5 of junit:junit")))
+		})
+	})
+
+	it("evaluates artifacts", func() {
+		policy := distzip.Policy{Deny: []distzip.DenyRule{
+			{Artifact: "org.apache.logging.log4j:log4j-core", Versions: "< 2.17.1", Reason: "CVE-2021-44228"},
+			{Artifact: "com.example:*"},
+		}}
+
+		violations, unverified := policy.Evaluate([]distzip.Artifact{
+			{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.14.1"},
+			{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.17.1-rc1"},
+			{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.17.1"},
+			{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "unknown"},
+			{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-api", Version: "2.14.1"},
+			{GroupID: "com.example", ArtifactID: "alpha", Version: "1.0"},
+			{ArtifactID: "log4j-core", Version: "2.14.1"},
+			{ArtifactID: "alpha", Version: "1.0"},
+		})
+
+		Expect(violations).To(Equal([]distzip.PolicyViolation{
+			{
+				Artifact: distzip.Artifact{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.14.1"},
+				Rule:     policy.Deny[0],
+			},
+			{
+				Artifact: distzip.Artifact{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.17.1-rc1"},
+				Rule:     policy.Deny[0],
+			},
+			{
+				Artifact: distzip.Artifact{GroupID: "com.example", ArtifactID: "alpha", Version: "1.0"},
+				Rule:     policy.Deny[1],
+			},
+			{
+				Artifact: distzip.Artifact{ArtifactID: "log4j-core", Version: "2.14.1"},
+				Rule:     policy.Deny[0],
+			},
+		}))
+		Expect(unverified).To(Equal([]distzip.PolicyViolation{
+			{
+				Artifact: distzip.Artifact{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "unknown"},
+				Rule:     policy.Deny[0],
+			},
+		}))
+	})
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// ProcessOptions contributes JVM options to the launch environment of a single process type.
+type ProcessOptions struct {
+	LayerContributor libpak.LayerContributor
+	Logger           bard.Logger
+	Type             string
+	Variable         string
+	Options          []string
+}
+
+func NewProcessOptions(processType string, variable string, options []string) ProcessOptions {
+	expected := map[string]interface{}{"type": processType, "variable": variable, "options": options}
+
+	return ProcessOptions{
+		LayerContributor: libpak.NewLayerContributor("Process Options", expected, libcnb.LayerTypes{Launch: true}),
+		Type:             processType,
+		Variable:         variable,
+		Options:          options,
+	}
+}
+
+func (p ProcessOptions) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	p.LayerContributor.Logger = p.Logger
+
+	return p.LayerContributor.Contribute(layer, func() (libcnb.Layer, error) {
+		layer.LaunchEnvironment.ProcessAppend(p.Type, p.Variable, " ", strings.Join(p.Options, " "))
+		return layer, nil
+	})
+}
+
+func (p ProcessOptions) Name() string {
+	return p.Type
+}
+
+// NewOptionsProcess returns a process of type processType that runs base with additional JVM options.  Processes that
+// start java directly receive the options as arguments, all others read them from variable in the launch environment
+// contributed by the returned ProcessOptions.
+func NewOptionsProcess(base libcnb.Process, processType string, variable string, options []string) (libcnb.Process, ProcessOptions, bool) {
+	p := libcnb.Process{
+		Type:             processType,
+		Command:          base.Command,
+		Arguments:        base.Arguments,
+		Direct:           base.Direct,
+		WorkingDirectory: base.WorkingDirectory,
+	}
+
+	if base.Direct && base.Command == "java" {
+		p.Arguments = append(append([]string{}, options...), base.Arguments...)
+		return p, ProcessOptions{}, false
+	}
+
+	return p, NewProcessOptions(processType, variable, options), true
+}
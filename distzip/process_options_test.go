@@ -0,0 +1,73 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testProcessOptions(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	it("contributes process specific options", func() {
+		ctx := libcnb.BuildContext{Layers: libcnb.Layers{Path: t.TempDir()}}
+
+		layer, err := ctx.Layers.Layer("test-layer")
+		Expect(err).NotTo(HaveOccurred())
+
+		p := distzip.NewProcessOptions("debug", "APP_OPTS", []string{"-Dalpha=bravo", "-Dcharlie=delta"})
+		Expect(p.Name()).To(Equal("debug"))
+
+		layer, err = p.Contribute(layer)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(layer.Launch).To(BeTrue())
+		Expect(layer.LaunchEnvironment).To(HaveKeyWithValue("debug/APP_OPTS.append", "-Dalpha=bravo -Dcharlie=delta"))
+		Expect(layer.LaunchEnvironment).To(HaveKeyWithValue("debug/APP_OPTS.delim", " "))
+	})
+
+	context("NewOptionsProcess", func() {
+		it("passes options through the environment", func() {
+			p, po, ok := distzip.NewOptionsProcess(
+				libcnb.Process{Type: "web", Command: "/workspace/app/bin/app", Arguments: []string{"server"}, Default: true, WorkingDirectory: "/workspace/app"},
+				"debug", "APP_OPTS", []string{"-Dalpha=bravo"})
+
+			Expect(ok).To(BeTrue())
+			Expect(p).To(Equal(libcnb.Process{Type: "debug", Command: "/workspace/app/bin/app", Arguments: []string{"server"}, WorkingDirectory: "/workspace/app"}))
+			Expect(po.Type).To(Equal("debug"))
+			Expect(po.Variable).To(Equal("APP_OPTS"))
+			Expect(po.Options).To(Equal([]string{"-Dalpha=bravo"}))
+		})
+
+		it("passes options as arguments to java", func() {
+			p, _, ok := distzip.NewOptionsProcess(
+				libcnb.Process{Type: "web", Command: "java", Arguments: []string{"-jar", "app.jar"}, Direct: true, Default: true},
+				"debug", "JAVA_OPTS", []string{"-Dalpha=bravo"})
+
+			Expect(ok).To(BeFalse())
+			Expect(p).To(Equal(libcnb.Process{Type: "debug", Command: "java", Arguments: []string{"-Dalpha=bravo", "-jar", "app.jar"}, Direct: true}))
+		})
+	})
+}
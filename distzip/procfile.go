@@ -0,0 +1,108 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+var procfileEntry = regexp.MustCompile(`^([A-Za-z0-9_.-]+):\s*(.+)$`)
+
+type ProcfileResolver struct {
+	Home   string
+	Logger bard.Logger
+}
+
+// Resolve returns a process for each entry of the Procfile in the root of the distribution, and false if there is no
+// Procfile.  The first word of each command is the launcher.  A launcher containing a path separator is resolved
+// relative to the root of the distribution and must exist, while a bare command name such as java is left to $PATH.
+// The web process, or the first process if there is none, is the default.
+func (p ProcfileResolver) Resolve() ([]libcnb.Process, bool, error) {
+	file := filepath.Join(p.Home, "Procfile")
+
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("unable to open %s\n%w", file, err)
+	}
+	defer f.Close()
+
+	var processes []libcnb.Process
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		m := procfileEntry.FindStringSubmatch(line)
+		if m == nil {
+			return nil, false, fmt.Errorf("invalid entry on line %d of %s: %q", n, file, line)
+		}
+
+		words := splitWords(m[2])
+		if len(words) == 0 {
+			return nil, false, fmt.Errorf("empty command for %s on line %d of %s", m[1], n, file)
+		}
+
+		launcher := words[0]
+		if strings.ContainsRune(launcher, '/') {
+			if !filepath.IsAbs(launcher) {
+				launcher = filepath.Join(p.Home, launcher)
+			}
+			if _, err := os.Stat(launcher); err != nil {
+				return nil, false, fmt.Errorf("launcher %s of process %s in %s does not exist\n%w", words[0], m[1], file, err)
+			}
+		}
+
+		process := libcnb.Process{Type: m[1], Command: launcher}
+		if len(words) > 1 {
+			process.Arguments = words[1:]
+		}
+
+		p.Logger.Debugf("Procfile process %s: %s %s", process.Type, process.Command, process.Arguments)
+		processes = append(processes, process)
+	}
+	if err := s.Err(); err != nil {
+		return nil, false, fmt.Errorf("unable to read %s\n%w", file, err)
+	}
+
+	if len(processes) == 0 {
+		return nil, false, fmt.Errorf("%s contains no processes", file)
+	}
+
+	def := 0
+	for i, process := range processes {
+		if process.Type == "web" {
+			def = i
+			break
+		}
+	}
+	processes[def].Default = true
+
+	return processes, true, nil
+}
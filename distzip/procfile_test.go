@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testProcfile(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		r distzip.ProcfileResolver
+	)
+
+	it.Before(func() {
+		r.Home = t.TempDir()
+
+		Expect(os.MkdirAll(filepath.Join(r.Home, "bin"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(r.Home, "bin", "app"), []byte("#!/bin/sh\n"), 0755)).To(Succeed())
+	})
+
+	it("returns false without a Procfile", func() {
+		_, ok, err := r.Resolve()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeFalse())
+	})
+
+	it("returns processes", func() {
+		Expect(os.WriteFile(filepath.Join(r.Home, "Procfile"), []byte(`# entry points
+worker: bin/app worker --queue "high priority"
+web: bin/app server
+`), 0644)).To(Succeed())
+
+		processes, ok, err := r.Resolve()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeTrue())
+		Expect(processes).To(Equal([]libcnb.Process{
+			{Type: "worker", Command: filepath.Join(r.Home, "bin", "app"), Arguments: []string{"worker", "--queue", "high priority"}},
+			{Type: "web", Command: filepath.Join(r.Home, "bin", "app"), Arguments: []string{"server"}, Default: true},
+		}))
+	})
+
+	it("makes the first process the default without a web process", func() {
+		Expect(os.WriteFile(filepath.Join(r.Home, "Procfile"), []byte("worker: bin/app\nbatch: bin/app batch\n"), 0644)).To(Succeed())
+
+		processes, ok, err := r.Resolve()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeTrue())
+		Expect(processes[0].Default).To(BeTrue())
+		Expect(processes[1].Default).To(BeFalse())
+	})
+
+	it("leaves bare command names to $PATH", func() {
+		Expect(os.WriteFile(filepath.Join(r.Home, "Procfile"), []byte("web: java -jar lib/app.jar\n"), 0644)).To(Succeed())
+
+		processes, ok, err := r.Resolve()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeTrue())
+		Expect(processes).To(Equal([]libcnb.Process{
+			{Type: "web", Command: "java", Arguments: []string{"-jar", "lib/app.jar"}, Default: true},
+		}))
+	})
+
+	it("fails for a missing launcher", func() {
+		Expect(os.WriteFile(filepath.Join(r.Home, "Procfile"), []byte("web: bin/missing server\n"), 0644)).To(Succeed())
+
+		_, _, err := r.Resolve()
+		Expect(err).To(MatchError(ContainSubstring("launcher bin/missing of process web")))
+	})
+
+	it("fails for an invalid entry", func() {
+		Expect(os.WriteFile(filepath.Join(r.Home, "Procfile"), []byte("web bin/app\n"), 0644)).To(Succeed())
+
+		_, _, err := r.Resolve()
+		Expect(err).To(MatchError(ContainSubstring(`invalid entry on line 1`)))
+	})
+
+	it("fails for an empty Procfile", func() {
+		Expect(os.WriteFile(filepath.Join(r.Home, "Procfile"), []byte("# nothing\n"), 0644)).To(Succeed())
+
+		_, _, err := r.Resolve()
+		Expect(err).To(MatchError(ContainSubstring("contains no processes")))
+	})
+}
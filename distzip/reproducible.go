@@ -0,0 +1,108 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// DefaultSourceDateEpoch is the modification time used when $SOURCE_DATE_EPOCH is not set, 1980-01-01T00:00:01Z.
+var DefaultSourceDateEpoch = time.Unix(315532801, 0).UTC()
+
+// SourceDateEpoch returns the time described by $SOURCE_DATE_EPOCH, or DefaultSourceDateEpoch if it is not set.
+func SourceDateEpoch() (time.Time, error) {
+	s, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok || s == "" {
+		return DefaultSourceDateEpoch, nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse $SOURCE_DATE_EPOCH %q\n%w", s, err)
+	}
+
+	return time.Unix(i, 0).UTC(), nil
+}
+
+type Normalizer struct {
+	ApplicationPath string
+	Logger          bard.Logger
+
+	// Executables are files that stay executable even if their contents do not require it.
+	Executables []string
+}
+
+// Normalize sets the modification time of all files and directories in the application to t, directory modes to
+// 0755 and file modes to 0755 for executables and 0644 for everything else.  Symlinks are left untouched.
+func (n Normalizer) Normalize(t time.Time) error {
+	executables := map[string]bool{}
+	for _, e := range n.Executables {
+		executables[e] = true
+	}
+
+	count := 0
+
+	err := filepath.WalkDir(n.ApplicationPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == n.ApplicationPath {
+			return nil
+		}
+
+		var mode os.FileMode
+		switch {
+		case d.IsDir():
+			mode = 0755
+		case d.Type().IsRegular():
+			mode = 0644
+
+			ok, err := IsExecutable(path)
+			if err != nil {
+				return err
+			}
+			if ok || executables[path] {
+				mode = 0755
+			}
+		default:
+			return nil
+		}
+
+		if err := os.Chmod(path, mode); err != nil {
+			return fmt.Errorf("unable to chmod %s\n%w", path, err)
+		}
+		if err := os.Chtimes(path, t, t); err != nil {
+			return fmt.Errorf("unable to set modification time of %s\n%w", path, err)
+		}
+		count++
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to normalize %s\n%w", n.ApplicationPath, err)
+	}
+
+	n.Logger.Bodyf("Normalized modes and modification times of %d files to %s", count, t.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testReproducible(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	context("SourceDateEpoch", func() {
+		it("returns the default epoch", func() {
+			t.Setenv("SOURCE_DATE_EPOCH", "")
+
+			Expect(distzip.SourceDateEpoch()).To(Equal(time.Date(1980, time.January, 1, 0, 0, 1, 0, time.UTC)))
+		})
+
+		it("returns $SOURCE_DATE_EPOCH", func() {
+			t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+			Expect(distzip.SourceDateEpoch()).To(Equal(time.Unix(1700000000, 0).UTC()))
+		})
+
+		it("fails for an invalid $SOURCE_DATE_EPOCH", func() {
+			t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+
+			_, err := distzip.SourceDateEpoch()
+			Expect(err).To(MatchError(ContainSubstring(`unable to parse $SOURCE_DATE_EPOCH "yesterday"`)))
+		})
+	})
+
+	context("Normalizer", func() {
+		var n distzip.Normalizer
+
+		it.Before(func() {
+			n.ApplicationPath = t.TempDir()
+
+			Expect(os.MkdirAll(filepath.Join(n.ApplicationPath, "app", "bin"), 0700)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(n.ApplicationPath, "app", "lib"), 0777)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(n.ApplicationPath, "app", "bin", "app"), []byte("#!/bin/sh\n"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(n.ApplicationPath, "app", "bin", "app.bat"), []byte("@echo off\r\n"), 0777)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(n.ApplicationPath, "app", "lib", "app.jar"), []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(n.ApplicationPath, "app", "lib", "launcher"), []byte{}, 0600)).To(Succeed())
+			writeELF(t, filepath.Join(n.ApplicationPath, "app", "lib", "helper"), elf.EM_X86_64)
+			Expect(os.Chmod(filepath.Join(n.ApplicationPath, "app", "lib", "helper"), 0700)).To(Succeed())
+			Expect(os.Symlink("app", filepath.Join(n.ApplicationPath, "app", "bin", "app-latest"))).To(Succeed())
+
+			n.Executables = []string{filepath.Join(n.ApplicationPath, "app", "lib", "launcher")}
+		})
+
+		it("normalizes modes and modification times", func() {
+			epoch := time.Unix(1700000000, 0)
+			Expect(n.Normalize(epoch)).To(Succeed())
+
+			for path, mode := range map[string]string{
+				"app":              "drwxr-xr-x",
+				"app/bin":          "drwxr-xr-x",
+				"app/bin/app":      "-rwxr-xr-x",
+				"app/bin/app.bat":  "-rw-r--r--",
+				"app/lib":          "drwxr-xr-x",
+				"app/lib/app.jar":  "-rw-r--r--",
+				"app/lib/helper":   "-rwxr-xr-x",
+				"app/lib/launcher": "-rwxr-xr-x",
+			} {
+				info, err := os.Stat(filepath.Join(n.ApplicationPath, path))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().String()).To(Equal(mode), path)
+				Expect(info.ModTime().Equal(epoch)).To(BeTrue(), path)
+			}
+
+			info, err := os.Lstat(filepath.Join(n.ApplicationPath, "app", "bin", "app-latest"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode() & os.ModeSymlink).NotTo(BeZero())
+		})
+	})
+}
@@ -18,18 +18,57 @@ package distzip
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/paketo-buildpacks/libpak"
 	"github.com/paketo-buildpacks/libpak/bard"
 )
 
+// DefaultScriptPatterns are the locations searched for an application script, in order of precedence, when
+// $BP_APPLICATION_SCRIPT is not set: the root of the application if it contains jars in lib, one directory down, and
+// the install locations of Gradle, sbt native packager and the Maven appassembler plugin.
+var DefaultScriptPatterns = []string{
+	"bin/*",
+	"*/bin/*",
+	"build/install/*/bin/*",
+	"target/universal/stage/bin/*",
+	"target/appassembler/bin/*",
+}
+
 type ScriptResolver struct {
 	ApplicationPath       string
 	ConfigurationResolver libpak.ConfigurationResolver
 	Logger                bard.Logger
+
+	// Strict indicates that the default pattern matching more than one script is an error.  An explicitly configured
+	// pattern matching more than one script is always an error, and matching no scripts is an error unless AllowMissing
+	// is set.
+	Strict bool
+
+	// AllowMissing indicates that an explicitly configured pattern matching no scripts is not an error, such as during
+	// detection, when the build of a source application may still create the script.
+	AllowMissing bool
+
+	// SelectLatest indicates that when candidates are found in several versions of the same distribution, such as
+	// myapp-1.4.0 and myapp-1.5.0, only the candidates in the highest version are considered.
+	SelectLatest bool
+
+	// Application selects the candidates of the distribution with this name when candidates are found in several
+	// distributions, such as service-a and service-b.  A versioned distribution such as service-a-1.2.0 is also selected
+	// by its unversioned name.
+	Application string
+
+	// Location is the pattern that matched the script found by the last call to Resolve.
+	Location string
+
+	// Superseded are the distribution directories discarded by the last call to Resolve when SelectLatest is set.
+	Superseded []string
 }
 
 func (s *ScriptResolver) Resolve() (string, bool, error) {
@@ -40,37 +79,223 @@ func (s *ScriptResolver) Resolve() (string, bool, error) {
 		pattern    string
 	)
 
+	s.Location, s.Superseded = "", nil
+
 	if pattern, ok = s.ConfigurationResolver.Resolve("BP_APPLICATION_SCRIPT"); ok {
 		file := filepath.Join(s.ApplicationPath, pattern)
 		candidates, err = filepath.Glob(file)
 		if err != nil {
 			return "", false, fmt.Errorf("unable to find files with %s\n%w", pattern, err)
 		}
-	} else {
-		file := filepath.Join(s.ApplicationPath, pattern)
-		candidates, err = filepath.Glob(file)
-		if err != nil {
-			return "", false, fmt.Errorf("unable to find files with %s\n%w", pattern, err)
+
+		if candidates, err = s.canonicalize(candidates, true); err != nil {
+			return "", false, err
 		}
+	} else {
+		for _, pattern = range DefaultScriptPatterns {
+			file := filepath.Join(s.ApplicationPath, pattern)
+			candidates, err = filepath.Glob(file)
+			if err != nil {
+				return "", false, fmt.Errorf("unable to find files with %s\n%w", pattern, err)
+			}
+
+			candidates = slices.DeleteFunc(candidates, func(c string) bool {
+				return strings.HasSuffix(c, ".bat")
+			})
+
+			if candidates, err = s.canonicalize(candidates, false); err != nil {
+				return "", false, err
+			}
+
+			if pattern == "bin/*" && len(candidates) > 0 && !s.isDistribution(s.ApplicationPath) {
+				s.Logger.Debugf("ignoring %s: application root contains no lib/*.jar", pattern)
+				candidates = nil
+			}
 
-		i := 0
-		for i < len(candidates) {
-			if strings.HasSuffix(candidates[i], ".bat") {
-				candidates = append(candidates[:i], candidates[i+1:]...)
+			if len(candidates) > 0 {
+				break
 			}
-			i++
+			s.Logger.Debugf("no application scripts in %s", pattern)
 		}
 	}
 
+	if len(candidates) > 0 {
+		s.Location = pattern
+	}
+
+	if s.SelectLatest && len(candidates) > 1 {
+		candidates, s.Superseded = s.selectLatest(candidates)
+	}
+
+	if s.Application != "" && len(candidates) > 0 {
+		selected := slices.DeleteFunc(slices.Clone(candidates), func(c string) bool {
+			return !s.isApplication(c, s.Application)
+		})
+		if len(selected) == 0 {
+			return "", false, ApplicationNotFoundError{Name: s.Application, Applications: s.applications(candidates)}
+		}
+		candidates = selected
+	}
+
 	switch len(candidates) {
 	case 0:
+		if ok && !s.AllowMissing {
+			return "", false, ScriptNotFoundError{Pattern: pattern}
+		} else if ok {
+			s.Logger.Debugf("no application scripts match %s yet", pattern)
+		}
 		return "", false, nil
 	case 1:
 		return candidates[0], true, nil
 	default:
 		sort.Strings(candidates)
-		s.Logger.Debugf("too many application scripts in %s, candidates: %s", pattern, candidates)
-		s.Logger.Debug("set a more strict `$BP_APPLICATION_SCRIPT` pattern that only matches a single script")
+
+		e := AmbiguousScriptError{Pattern: pattern, Candidates: candidates}
+		if applications := s.applications(candidates); len(applications) > 1 {
+			e.Applications = applications
+		}
+
+		if s.Strict || ok {
+			return "", false, e
+		}
+		s.Logger.Debug(e.Error())
 		return "", false, nil
 	}
 }
+
+// canonicalize resolves symlinks in candidates, merging candidates that are aliases of the same file, ignores candidates
+// that are not regular files, and rejects candidates that resolve to a file outside of the application.  Rejected candidates are an error if explicit is true.
+func (s *ScriptResolver) canonicalize(candidates []string, explicit bool) ([]string, error) {
+	root, err := filepath.EvalSymlinks(s.ApplicationPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s\n%w", s.ApplicationPath, err)
+	}
+
+	var canonical []string
+	for _, c := range candidates {
+		real, err := filepath.EvalSymlinks(c)
+		if err != nil {
+			s.Logger.Debugf("ignoring %s: %s", c, err)
+			continue
+		}
+
+		if info, err := os.Stat(real); err != nil || !info.Mode().IsRegular() {
+			s.Logger.Debugf("ignoring %s: not a regular file", c)
+			continue
+		}
+
+		rel, err := filepath.Rel(root, real)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			if explicit {
+				return nil, ScriptOutsideApplicationError{Path: c, Target: real}
+			}
+			s.Logger.Debugf("ignoring %s: resolves to %s outside of the application", c, real)
+			continue
+		}
+
+		path := filepath.Join(s.ApplicationPath, rel)
+		if path != c {
+			s.Logger.Debugf("%s is an alias of %s", c, path)
+		}
+		if !slices.Contains(canonical, path) {
+			canonical = append(canonical, path)
+		}
+	}
+
+	return canonical, nil
+}
+
+// isDistribution returns whether dir looks like a JVM distribution, containing jars in lib or, for jpackage app-images,
+// in lib/app.
+func (s *ScriptResolver) isDistribution(dir string) bool {
+	for _, pattern := range []string{"lib/*.jar", "lib/app/*.jar"} {
+		if matches, err := filepath.Glob(filepath.Join(dir, pattern)); err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+var versionedDistribution = regexp.MustCompile(`^(.+?)-v?(\d+(?:\.\d+)*(?:[-+][0-9A-Za-z.+-]*)?)$`)
+
+// selectLatest groups candidates by the application name of their distribution directory and discards the candidates
+// of all but the highest semantic version in each group.  Candidates whose distribution directory is not versioned are
+// always kept.
+func (s *ScriptResolver) selectLatest(candidates []string) ([]string, []string) {
+	type distribution struct {
+		name    string
+		version *semver.Version
+	}
+
+	distributions := map[string]distribution{}
+	latest := map[string]*semver.Version{}
+
+	for _, c := range candidates {
+		dir := filepath.Dir(filepath.Dir(c))
+
+		m := versionedDistribution.FindStringSubmatch(filepath.Base(dir))
+		if m == nil {
+			continue
+		}
+
+		v, err := semver.NewVersion(m[2])
+		if err != nil {
+			s.Logger.Debugf("ignoring version of %s: %s", dir, err)
+			continue
+		}
+
+		distributions[dir] = distribution{name: m[1], version: v}
+		if l, ok := latest[m[1]]; !ok || v.GreaterThan(l) {
+			latest[m[1]] = v
+		}
+	}
+
+	var (
+		selected   []string
+		superseded []string
+	)
+
+	for _, c := range candidates {
+		dir := filepath.Dir(filepath.Dir(c))
+
+		d, ok := distributions[dir]
+		if !ok || d.version.Equal(latest[d.name]) {
+			selected = append(selected, c)
+			continue
+		}
+
+		if !slices.Contains(superseded, dir) {
+			s.Logger.Debugf("%s is superseded by version %s", dir, latest[d.name])
+			superseded = append(superseded, dir)
+		}
+	}
+
+	sort.Strings(superseded)
+	return selected, superseded
+}
+
+// applications returns the sorted names of the distribution directories containing candidates.
+func (s *ScriptResolver) applications(candidates []string) []string {
+	var names []string
+	for _, c := range candidates {
+		if name := filepath.Base(filepath.Dir(filepath.Dir(c))); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// isApplication returns whether the distribution directory containing candidate is named name, with or without a
+// version suffix.
+func (s *ScriptResolver) isApplication(candidate string, name string) bool {
+	dir := filepath.Base(filepath.Dir(filepath.Dir(candidate)))
+	if dir == name {
+		return true
+	}
+
+	m := versionedDistribution.FindStringSubmatch(dir)
+	return m != nil && m[1] == name
+}
@@ -63,6 +63,72 @@ func testScriptResolver(t *testing.T, context spec.G, it spec.S) {
 
 		Expect(ok).To(BeTrue())
 		Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "app", "bin", "alpha.sh")))
+		Expect(r.Location).To(Equal("*/bin/*"))
+	})
+
+	context("default locations", func() {
+		it("returns script from the application root", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "bin"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "lib"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "lib", "app.jar"), []byte{}, 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "bin", "alpha"), []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "bin", "alpha.bat"), []byte{}, 0755)).To(Succeed())
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "bin", "alpha")))
+			Expect(r.Location).To(Equal("bin/*"))
+		})
+
+		it("prefers the application root over nested distributions", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "bin"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "lib"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "lib", "app.jar"), []byte{}, 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "bin", "alpha"), []byte{}, 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "app", "bin", "bravo"), []byte{}, 0755)).To(Succeed())
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "bin", "alpha")))
+		})
+
+		it("ignores the application root without jars", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "bin", "www"), []byte{}, 0755)).To(Succeed())
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("ignores directories", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "bin", "com"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "lib"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "lib", "app.jar"), []byte{}, 0644)).To(Succeed())
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("returns script from build output locations", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "build", "install", "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "build", "install", "app", "bin", "alpha"), []byte{}, 0755)).To(Succeed())
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "build", "install", "app", "bin", "alpha")))
+			Expect(r.Location).To(Equal("build/install/*/bin/*"))
+		})
 	})
 
 	context("$BP_APPLICATION_SCRIPT", func() {
@@ -81,6 +147,35 @@ func testScriptResolver(t *testing.T, context spec.G, it spec.S) {
 			Expect(ok).To(BeTrue())
 			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "bin", "alpha.bat")))
 		})
+
+		it("fails for no script", func() {
+			_, _, err := r.Resolve()
+			Expect(err).To(MatchError(distzip.ScriptNotFoundError{Pattern: filepath.Join("bin", "*.bat")}))
+		})
+
+		it("returns false for no script when missing scripts are allowed", func() {
+			r.AllowMissing = true
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("fails for too many scripts", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "bin", "alpha.bat"), []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "bin", "bravo.bat"), []byte{}, 0755)).To(Succeed())
+
+			_, _, err := r.Resolve()
+			Expect(err).To(MatchError(distzip.AmbiguousScriptError{
+				Pattern: filepath.Join("bin", "*.bat"),
+				Candidates: []string{
+					filepath.Join(r.ApplicationPath, "bin", "alpha.bat"),
+					filepath.Join(r.ApplicationPath, "bin", "bravo.bat"),
+				},
+			}))
+		})
 	})
 
 	it("returns false for no script", func() {
@@ -107,4 +202,167 @@ func testScriptResolver(t *testing.T, context spec.G, it spec.S) {
 		Expect(buf.String()).To(ContainSubstring("set a more strict `$BP_APPLICATION_SCRIPT` pattern that only matches a single script"))
 	})
 
+	context("symlinks", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "app", "bin", "alpha"), []byte{}, 0755)).To(Succeed())
+		})
+
+		it("merges aliases of the same script", func() {
+			Expect(os.Symlink("alpha", filepath.Join(r.ApplicationPath, "app", "bin", "alpha-latest"))).To(Succeed())
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "app", "bin", "alpha")))
+		})
+
+		it("ignores scripts resolving outside of the application", func() {
+			outside := filepath.Join(t.TempDir(), "bravo")
+			Expect(os.WriteFile(outside, []byte{}, 0755)).To(Succeed())
+			Expect(os.Symlink(outside, filepath.Join(r.ApplicationPath, "app", "bin", "bravo"))).To(Succeed())
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "app", "bin", "alpha")))
+		})
+
+		it("fails for a configured script resolving outside of the application", func() {
+			outside := t.TempDir()
+			Expect(os.WriteFile(filepath.Join(outside, "bravo"), []byte{}, 0755)).To(Succeed())
+			rel, err := filepath.Rel(r.ApplicationPath, filepath.Join(outside, "bravo"))
+			Expect(err).NotTo(HaveOccurred())
+			t.Setenv("BP_APPLICATION_SCRIPT", rel)
+
+			_, _, err = r.Resolve()
+			Expect(err).To(MatchError(ContainSubstring("outside of the application")))
+		})
+	})
+
+	context("select latest", func() {
+		it.Before(func() {
+			r.SelectLatest = true
+
+			for _, dir := range []string{"myapp-1.4.0", "myapp-1.10.0", "myapp-1.5.0-rc.1"} {
+				Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, dir, "bin"), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(r.ApplicationPath, dir, "bin", "myapp"), []byte{}, 0755)).To(Succeed())
+			}
+		})
+
+		it("returns script from the highest version", func() {
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "myapp-1.10.0", "bin", "myapp")))
+			Expect(r.Superseded).To(Equal([]string{
+				filepath.Join(r.ApplicationPath, "myapp-1.4.0"),
+				filepath.Join(r.ApplicationPath, "myapp-1.5.0-rc.1"),
+			}))
+		})
+
+		it("keeps unversioned distributions", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "other", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "other", "bin", "other"), []byte{}, 0755)).To(Succeed())
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("does not select when disabled", func() {
+			r.SelectLatest = false
+
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+			Expect(r.Superseded).To(BeEmpty())
+		})
+	})
+
+	context("several applications", func() {
+		it.Before(func() {
+			for _, dir := range []string{"service-a", "service-b-1.2.0"} {
+				Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, dir, "bin"), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(r.ApplicationPath, dir, "bin", "service"), []byte{}, 0755)).To(Succeed())
+			}
+		})
+
+		it("returns script of the selected application", func() {
+			r.Application = "service-a"
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "service-a", "bin", "service")))
+		})
+
+		it("returns script of the selected versioned application", func() {
+			r.Application = "service-b"
+
+			s, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(filepath.Join(r.ApplicationPath, "service-b-1.2.0", "bin", "service")))
+		})
+
+		it("fails for an unknown application", func() {
+			r.Application = "service-c"
+
+			_, _, err := r.Resolve()
+			Expect(err).To(MatchError(distzip.ApplicationNotFoundError{
+				Name:         "service-c",
+				Applications: []string{"service-a", "service-b-1.2.0"},
+			}))
+		})
+
+		it("fails for too many scripts listing the applications", func() {
+			r.Strict = true
+
+			_, _, err := r.Resolve()
+			Expect(err).To(MatchError(distzip.AmbiguousScriptError{
+				Pattern: "*/bin/*",
+				Candidates: []string{
+					filepath.Join(r.ApplicationPath, "service-a", "bin", "service"),
+					filepath.Join(r.ApplicationPath, "service-b-1.2.0", "bin", "service"),
+				},
+				Applications: []string{"service-a", "service-b-1.2.0"},
+			}))
+		})
+	})
+
+	context("strict", func() {
+		it.Before(func() {
+			r.Strict = true
+		})
+
+		it("returns false for no script with the default pattern", func() {
+			_, ok, err := r.Resolve()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ok).To(BeFalse())
+		})
+
+		it("fails for too many scripts", func() {
+			Expect(os.MkdirAll(filepath.Join(r.ApplicationPath, "app", "bin"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "app", "bin", "alpha"), []byte{}, 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(r.ApplicationPath, "app", "bin", "bravo"), []byte{}, 0755)).To(Succeed())
+
+			_, _, err := r.Resolve()
+			Expect(err).To(MatchError(distzip.AmbiguousScriptError{
+				Pattern: "*/bin/*",
+				Candidates: []string{
+					filepath.Join(r.ApplicationPath, "app", "bin", "alpha"),
+					filepath.Join(r.ApplicationPath, "app", "bin", "bravo"),
+				},
+			}))
+		})
+	})
 }
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// SpringBoot describes the executable Spring Boot jar started by a distribution, such as one created by bootDistZip.
+type SpringBoot struct {
+	Jar        string
+	Version    string
+	StartClass string
+}
+
+// PlanMetadata returns the Spring Boot metadata to record in the build plan, with the jar relative to the application.
+func (s SpringBoot) PlanMetadata(applicationPath string) map[string]interface{} {
+	jar := s.Jar
+	if rel, err := filepath.Rel(applicationPath, s.Jar); err == nil {
+		jar = rel
+	}
+
+	return map[string]interface{}{
+		"jar":         jar,
+		"version":     s.Version,
+		"start-class": s.StartClass,
+	}
+}
+
+// Labels returns the image labels describing the Spring Boot application.
+func (s SpringBoot) Labels() []libcnb.Label {
+	labels := []libcnb.Label{{Key: "org.springframework.boot.version", Value: s.Version}}
+
+	if s.StartClass != "" {
+		labels = append(labels, libcnb.Label{Key: "org.springframework.boot.start-class", Value: s.StartClass})
+	}
+
+	return labels
+}
+
+type SpringBootResolver struct {
+	Logger bard.Logger
+}
+
+// Resolve returns the Spring Boot metadata of the jar started by the launch, either its main jar or the only entry of
+// its classpath, and false if it does not start an executable Spring Boot jar.
+func (s SpringBootResolver) Resolve(launch Launch) (SpringBoot, bool, error) {
+	jar := launch.MainJar
+	if jar == "" && len(launch.ClassPath) == 1 {
+		jar = launch.ClassPath[0]
+	}
+
+	if jar == "" {
+		return SpringBoot{}, false, nil
+	}
+
+	if _, err := os.Stat(jar); os.IsNotExist(err) {
+		s.Logger.Debugf("jar %s does not exist", jar)
+		return SpringBoot{}, false, nil
+	} else if err != nil {
+		return SpringBoot{}, false, fmt.Errorf("unable to stat %s\n%w", jar, err)
+	}
+
+	m, err := ReadManifest(jar)
+	if err != nil {
+		return SpringBoot{}, false, fmt.Errorf("unable to read manifest of %s\n%w", jar, err)
+	}
+
+	if m["Spring-Boot-Version"] == "" {
+		return SpringBoot{}, false, nil
+	}
+
+	return SpringBoot{
+		Jar:        jar,
+		Version:    m["Spring-Boot-Version"],
+		StartClass: m["Start-Class"],
+	}, true, nil
+}
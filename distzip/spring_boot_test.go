@@ -0,0 +1,107 @@
+/*
+ * Copyright 2018-2024 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package distzip_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/dist-zip/v5/distzip"
+)
+
+func testSpringBoot(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		path string
+		r    distzip.SpringBootResolver
+	)
+
+	it.Before(func() {
+		path = t.TempDir()
+	})
+
+	it("returns false without a main jar", func() {
+		_, ok, err := r.Resolve(distzip.Launch{
+			MainClass: "com.example.Main",
+			ClassPath: []string{filepath.Join(path, "alpha.jar"), filepath.Join(path, "bravo.jar")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeFalse())
+	})
+
+	it("returns false if the main jar does not exist", func() {
+		_, ok, err := r.Resolve(distzip.Launch{MainJar: filepath.Join(path, "app.jar")})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeFalse())
+	})
+
+	it("returns false for a jar that is not a Spring Boot jar", func() {
+		writeJar(t, filepath.Join(path, "app.jar"), map[string]string{"META-INF/MANIFEST.MF": "Main-Class: com.example.Main\n"})
+
+		_, ok, err := r.Resolve(distzip.Launch{MainJar: filepath.Join(path, "app.jar")})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeFalse())
+	})
+
+	it("describes a Spring Boot jar", func() {
+		writeJar(t, filepath.Join(path, "lib", "app.jar"), map[string]string{
+			"META-INF/MANIFEST.MF": "Main-Class: org.springframework.boot.loader.launch.JarLauncher\nStart-Class: com.example.Main\nSpring-Boot-Version: 3.2.1\n",
+		})
+
+		boot, ok, err := r.Resolve(distzip.Launch{MainJar: filepath.Join(path, "lib", "app.jar")})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeTrue())
+		Expect(boot).To(Equal(distzip.SpringBoot{
+			Jar:        filepath.Join(path, "lib", "app.jar"),
+			Version:    "3.2.1",
+			StartClass: "com.example.Main",
+		}))
+		Expect(boot.PlanMetadata(path)).To(Equal(map[string]interface{}{
+			"jar":         filepath.Join("lib", "app.jar"),
+			"version":     "3.2.1",
+			"start-class": "com.example.Main",
+		}))
+		Expect(boot.Labels()).To(Equal([]libcnb.Label{
+			{Key: "org.springframework.boot.version", Value: "3.2.1"},
+			{Key: "org.springframework.boot.start-class", Value: "com.example.Main"},
+		}))
+	})
+
+	it("describes a Spring Boot jar started by its launcher class", func() {
+		writeJar(t, filepath.Join(path, "lib", "app.jar"), map[string]string{
+			"META-INF/MANIFEST.MF": "Main-Class: org.springframework.boot.loader.JarLauncher\nStart-Class: com.example.Main\nSpring-Boot-Version: 2.7.18\n",
+		})
+
+		boot, ok, err := r.Resolve(distzip.Launch{
+			MainClass: "org.springframework.boot.loader.JarLauncher",
+			ClassPath: []string{filepath.Join(path, "lib", "app.jar")},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ok).To(BeTrue())
+		Expect(boot.Version).To(Equal("2.7.18"))
+	})
+}